@@ -2,17 +2,15 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
-	"os"
 	"strings"
-
-	"github.com/coreos/go-oidc/v3/oidc"
 )
 
-// Middleware validates JWT tokens using Keycloak OIDC provider
-func Middleware(next http.Handler) http.Handler {
+// Middleware validates JWT tokens using the Keycloak OIDC provider
+func (v *Verifier) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
@@ -27,46 +25,44 @@ func Middleware(next http.Handler) http.Handler {
 		}
 
 		tokenString := parts[1]
-		ctx := context.Background()
-
-		issuer := os.Getenv("KEYCLOAK_ISSUER")
 
-		log.Printf("[AUTH] Attempting to validate token with issuer: %s", issuer)
-
-		provider, err := oidc.NewProvider(ctx, issuer)
+		claims, err := v.Verify(r.Context(), tokenString)
 		if err != nil {
-			log.Printf("[AUTH] Failed to get OIDC provider from %s: %v", issuer, err)
-			http.Error(w, fmt.Sprintf("Failed to get provider: %v", err), http.StatusInternalServerError)
+			if errors.Is(err, ErrProviderUnavailable) {
+				http.Error(w, "Authentication service unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			log.Printf("[AUTH] Token verification failed: %v", err)
+			http.Error(w, fmt.Sprintf("Invalid token: %v", err), http.StatusUnauthorized)
 			return
 		}
 
-		// Skip audience check since access tokens have audience "account" not the client ID
-		verifier := provider.Verifier(&oidc.Config{
-			SkipClientIDCheck: true,
-		})
+		log.Printf("[AUTH] Token validated successfully for user: %s (roles: %v)", claims.Subject, claims.Roles)
+		ctx := context.WithValue(r.Context(), UserIDKey, claims.Subject)
+		ctx = context.WithValue(ctx, RolesKey, claims.Roles)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
 
-		idToken, err := verifier.Verify(ctx, tokenString)
-		if err != nil {
-			log.Printf("[AUTH] Token verification failed: %v", err)
-			if len(tokenString) > 50 {
-				log.Printf("[AUTH] Token (first 50 chars): %s...", tokenString[:50])
-			}
-			http.Error(w, fmt.Sprintf("Invalid token: %v", err), http.StatusUnauthorized)
+// OptionalMiddleware identifies the caller when a valid bearer token is present and
+// otherwise lets the request through anonymously, for public routes that personalise responses
+func (v *Verifier) OptionalMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenString, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || tokenString == "" {
+			next.ServeHTTP(w, r)
 			return
 		}
 
-		// Token is valid, proceed
-		claims := struct {
-			Sub string `json:"sub"`
-		}{}
-		if err := idToken.Claims(&claims); err != nil {
-			log.Printf("[AUTH] Failed to extract claims: %v", err)
-			http.Error(w, fmt.Sprintf("Invalid claims: %v", err), http.StatusUnauthorized)
+		claims, err := v.Verify(r.Context(), tokenString)
+		if err != nil {
+			log.Printf("[AUTH] Ignoring unverified token on public route: %v", err)
+			next.ServeHTTP(w, r)
 			return
 		}
 
-		log.Printf("[AUTH] Token validated successfully for user: %s", claims.Sub)
-		ctx = context.WithValue(r.Context(), UserIDKey, claims.Sub)
+		ctx := context.WithValue(r.Context(), UserIDKey, claims.Subject)
+		ctx = context.WithValue(ctx, RolesKey, claims.Roles)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -1,11 +1,96 @@
 package auth
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
 )
 
+// fakeKeycloak serves OIDC discovery and a JWKS endpoint backed by a rotatable RSA key
+type fakeKeycloak struct {
+	server         *httptest.Server
+	discoveryCalls atomic.Int32
+
+	mu  sync.Mutex
+	kid string
+	key *rsa.PrivateKey
+}
+
+func newFakeKeycloak(t *testing.T) *fakeKeycloak {
+	t.Helper()
+	fk := &fakeKeycloak{}
+	fk.rotateKey(t, "key-1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		fk.discoveryCalls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                fk.server.URL,
+			"jwks_uri":                              fk.server.URL + "/certs",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/certs", func(w http.ResponseWriter, r *http.Request) {
+		fk.mu.Lock()
+		defer fk.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"kid": fk.kid,
+				"n":   base64.RawURLEncoding.EncodeToString(fk.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(fk.key.E)).Bytes()),
+			}},
+		})
+	})
+	fk.server = httptest.NewServer(mux)
+	t.Cleanup(fk.server.Close)
+	return fk
+}
+
+func (fk *fakeKeycloak) rotateKey(t *testing.T, kid string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	fk.mu.Lock()
+	fk.kid = kid
+	fk.key = key
+	fk.mu.Unlock()
+}
+
+func (fk *fakeKeycloak) token(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	fk.mu.Lock()
+	defer fk.mu.Unlock()
+	if _, ok := claims["iss"]; !ok {
+		claims["iss"] = fk.server.URL
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = fk.kid
+	signed, err := token.SignedString(fk.key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
 func TestMiddleware_MissingHeader(t *testing.T) {
 	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -14,7 +99,7 @@ func TestMiddleware_MissingHeader(t *testing.T) {
 	req := httptest.NewRequest("GET", "/", nil)
 	rr := httptest.NewRecorder()
 
-	Middleware(next).ServeHTTP(rr, req)
+	NewVerifier(VerifierConfig{}).Middleware(next).ServeHTTP(rr, req)
 
 	if status := rr.Code; status != http.StatusUnauthorized {
 		t.Errorf("handler returned wrong status code: got %v want %v",
@@ -31,7 +116,7 @@ func TestMiddleware_InvalidHeaderFormat(t *testing.T) {
 	req.Header.Set("Authorization", "InvalidFormat")
 	rr := httptest.NewRecorder()
 
-	Middleware(next).ServeHTTP(rr, req)
+	NewVerifier(VerifierConfig{}).Middleware(next).ServeHTTP(rr, req)
 
 	if status := rr.Code; status != http.StatusUnauthorized {
 		t.Errorf("handler returned wrong status code: got %v want %v",
@@ -39,4 +124,232 @@ func TestMiddleware_InvalidHeaderFormat(t *testing.T) {
 	}
 }
 
-// TODO: Add test for valid token verification by mocking OIDC provider
+func TestMiddleware_ValidToken(t *testing.T) {
+	fk := newFakeKeycloak(t)
+	verifier := NewVerifier(VerifierConfig{IssuerURL: fk.server.URL, ClientID: "webapp", ClockSkew: time.Minute})
+
+	var gotUserID string
+	var gotRoles []string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserID, _ = GetUserIDFromContext(r.Context())
+		gotRoles = GetRolesFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := verifier.Middleware(next)
+
+	serve := func(token string) int {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		status := serve(fk.token(t, jwt.MapClaims{
+			"sub":          "user-123",
+			"exp":          time.Now().Add(time.Hour).Unix(),
+			"realm_access": map[string]interface{}{"roles": []string{RoleContentManager}},
+		}))
+		if status != http.StatusOK {
+			t.Fatalf("expected 200, got %d", status)
+		}
+		if gotUserID != "user-123" {
+			t.Errorf("expected user-123 in context, got %q", gotUserID)
+		}
+		if len(gotRoles) != 1 || gotRoles[0] != RoleContentManager {
+			t.Errorf("expected [%s] roles in context, got %v", RoleContentManager, gotRoles)
+		}
+	})
+
+	t.Run("Expired within clock skew", func(t *testing.T) {
+		status := serve(fk.token(t, jwt.MapClaims{
+			"sub": "user-123",
+			"exp": time.Now().Add(-30 * time.Second).Unix(),
+		}))
+		if status != http.StatusOK {
+			t.Errorf("expected 200, got %d", status)
+		}
+	})
+
+	t.Run("Expired beyond clock skew", func(t *testing.T) {
+		status := serve(fk.token(t, jwt.MapClaims{
+			"sub": "user-123",
+			"exp": time.Now().Add(-5 * time.Minute).Unix(),
+		}))
+		if status != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", status)
+		}
+	})
+
+	t.Run("Not yet valid within clock skew", func(t *testing.T) {
+		status := serve(fk.token(t, jwt.MapClaims{
+			"sub": "user-123",
+			"iat": time.Now().Add(30 * time.Second).Unix(),
+			"nbf": time.Now().Add(30 * time.Second).Unix(),
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}))
+		if status != http.StatusOK {
+			t.Errorf("expected 200, got %d", status)
+		}
+	})
+
+	t.Run("Not yet valid beyond clock skew", func(t *testing.T) {
+		status := serve(fk.token(t, jwt.MapClaims{
+			"sub": "user-123",
+			"nbf": time.Now().Add(5 * time.Minute).Unix(),
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}))
+		if status != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", status)
+		}
+	})
+
+	t.Run("Missing expiry", func(t *testing.T) {
+		status := serve(fk.token(t, jwt.MapClaims{"sub": "user-123"}))
+		if status != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", status)
+		}
+	})
+
+	t.Run("Wrong issuer", func(t *testing.T) {
+		status := serve(fk.token(t, jwt.MapClaims{
+			"iss": "http://evil.example.com",
+			"sub": "user-123",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}))
+		if status != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", status)
+		}
+	})
+
+	t.Run("Key rotation", func(t *testing.T) {
+		fk.rotateKey(t, "key-2")
+		status := serve(fk.token(t, jwt.MapClaims{
+			"sub": "user-123",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}))
+		if status != http.StatusOK {
+			t.Errorf("expected 200 after key rotation, got %d", status)
+		}
+	})
+
+	if calls := fk.discoveryCalls.Load(); calls != 1 {
+		t.Errorf("expected discovery to run once, ran %d times", calls)
+	}
+}
+
+func TestMiddleware_AudienceCheck(t *testing.T) {
+	fk := newFakeKeycloak(t)
+	verifier := NewVerifier(VerifierConfig{IssuerURL: fk.server.URL, Audience: "ai-dala-api"})
+	handler := verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for aud, want := range map[string]int{"ai-dala-api": http.StatusOK, "account": http.StatusUnauthorized} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Bearer "+fk.token(t, jwt.MapClaims{
+			"sub": "user-123",
+			"aud": aud,
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		if rr.Code != want {
+			t.Errorf("audience %q: expected %d, got %d", aud, want, rr.Code)
+		}
+	}
+}
+
+func TestMiddleware_ProviderUnavailable(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	verifier := NewVerifier(VerifierConfig{IssuerURL: down.URL, MaxRetries: 2, RetryDelay: time.Millisecond})
+	handler := verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Authorization", "Bearer some.token.value")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestOptionalMiddleware(t *testing.T) {
+	fk := newFakeKeycloak(t)
+	verifier := NewVerifier(VerifierConfig{IssuerURL: fk.server.URL})
+
+	var gotUserID string
+	handler := verifier.OptionalMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserID, _ = GetUserIDFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(authorization string) int {
+		gotUserID = ""
+		req := httptest.NewRequest("GET", "/", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	t.Run("Anonymous", func(t *testing.T) {
+		if status := serve(""); status != http.StatusOK || gotUserID != "" {
+			t.Errorf("expected anonymous 200, got %d with user %q", status, gotUserID)
+		}
+	})
+
+	t.Run("Valid token", func(t *testing.T) {
+		status := serve("Bearer " + fk.token(t, jwt.MapClaims{
+			"sub": "user-123",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}))
+		if status != http.StatusOK || gotUserID != "user-123" {
+			t.Errorf("expected user-123, got %d with user %q", status, gotUserID)
+		}
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		if status := serve("Bearer not.a.token"); status != http.StatusOK || gotUserID != "" {
+			t.Errorf("expected anonymous 200, got %d with user %q", status, gotUserID)
+		}
+	})
+}
+
+func TestVerifier_SharedDiscovery(t *testing.T) {
+	var calls atomic.Int32
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	verifier := NewVerifier(VerifierConfig{IssuerURL: down.URL, MaxRetries: 2, RetryDelay: 20 * time.Millisecond})
+
+	// Requests arriving during discovery wait for its result instead of each retrying
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := verifier.Discover(context.Background()); !errors.Is(err, ErrProviderUnavailable) {
+				t.Errorf("expected ErrProviderUnavailable, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got > 6 {
+		t.Errorf("expected discovery attempts to be shared, got %d requests", got)
+	}
+}
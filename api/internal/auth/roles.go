@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"net/http"
+)
+
+// Realm roles defined in keycloak/realm-export.json
+const (
+	RoleAdmin          = "ADMIN"
+	RoleContentManager = "CONTENT_MANAGER"
+)
+
+// Permission is an application-level capability granted through roles
+type Permission string
+
+const (
+	// PermManageContent allows creating, editing and deleting articles and news
+	PermManageContent Permission = "content:manage"
+	// PermPublishContent allows publishing and unpublishing articles and news
+	PermPublishContent Permission = "content:publish"
+	// PermManageTaxonomy allows managing categories and tags
+	PermManageTaxonomy Permission = "taxonomy:manage"
+)
+
+// rolePermissions maps Keycloak roles to the permissions they grant
+// ("Content Editor / Admin" in docs/requirements/roles.md)
+var rolePermissions = map[string][]Permission{
+	RoleAdmin:          {PermManageContent, PermPublishContent, PermManageTaxonomy},
+	RoleContentManager: {PermManageContent, PermPublishContent, PermManageTaxonomy},
+}
+
+const RolesKey contextKey = "roles"
+
+// GetRolesFromContext retrieves the caller's roles from the context
+func GetRolesFromContext(ctx context.Context) []string {
+	roles, _ := ctx.Value(RolesKey).([]string)
+	return roles
+}
+
+// HasRole reports whether the caller has at least one of the given roles
+func HasRole(ctx context.Context, roles ...string) bool {
+	for _, have := range GetRolesFromContext(ctx) {
+		for _, want := range roles {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasPermission reports whether any of the caller's roles grants the permission
+func HasPermission(ctx context.Context, perm Permission) bool {
+	for _, role := range GetRolesFromContext(ctx) {
+		for _, p := range rolePermissions[role] {
+			if p == perm {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RequireRole rejects requests whose caller has none of the given roles.
+// It must be wrapped by Middleware so that roles are present in the context.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !HasRole(r.Context(), roles...) {
+				log.Printf("[AUTH] Access denied: required one of roles %v", roles)
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequirePermission rejects requests whose caller lacks the given permission.
+// It must be wrapped by Middleware so that roles are present in the context.
+func RequirePermission(perm Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !HasPermission(r.Context(), perm) {
+				log.Printf("[AUTH] Access denied: missing permission %s", perm)
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// tokenRoleClaims mirrors the Keycloak role claims of an access token
+type tokenRoleClaims struct {
+	RealmAccess struct {
+		Roles []string `json:"roles"`
+	} `json:"realm_access"`
+	ResourceAccess map[string]struct {
+		Roles []string `json:"roles"`
+	} `json:"resource_access"`
+}
+
+// roles merges realm roles and the roles of the given client, without duplicates
+func (c tokenRoleClaims) roles(clientID string) []string {
+	seen := make(map[string]bool)
+	roles := []string{}
+	add := func(list []string) {
+		for _, role := range list {
+			if !seen[role] {
+				seen[role] = true
+				roles = append(roles, role)
+			}
+		}
+	}
+	add(c.RealmAccess.Roles)
+	if clientID != "" {
+		add(c.ResourceAccess[clientID].Roles)
+	}
+	return roles
+}
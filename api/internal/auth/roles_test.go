@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTokenRoleClaims_Roles(t *testing.T) {
+	raw := `{
+		"realm_access": {"roles": ["default-roles-ai-dala-realm", "CONTENT_MANAGER"]},
+		"resource_access": {
+			"webapp": {"roles": ["CONTENT_MANAGER", "uploader"]},
+			"account": {"roles": ["manage-account"]}
+		}
+	}`
+
+	var claims tokenRoleClaims
+	if err := json.Unmarshal([]byte(raw), &claims); err != nil {
+		t.Fatalf("failed to unmarshal claims: %v", err)
+	}
+
+	roles := claims.roles("webapp")
+	expected := []string{"default-roles-ai-dala-realm", "CONTENT_MANAGER", "uploader"}
+	if len(roles) != len(expected) {
+		t.Fatalf("expected roles %v, got %v", expected, roles)
+	}
+	for i := range expected {
+		if roles[i] != expected[i] {
+			t.Errorf("expected role %s at %d, got %s", expected[i], i, roles[i])
+		}
+	}
+
+	if got := claims.roles(""); len(got) != 2 {
+		t.Errorf("expected only realm roles without client id, got %v", got)
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := RequireRole(RoleAdmin)(next)
+
+	tests := []struct {
+		name   string
+		roles  []string
+		status int
+	}{
+		{"No roles", nil, http.StatusForbidden},
+		{"Other role", []string{RoleContentManager}, http.StatusForbidden},
+		{"Admin", []string{"default-roles-ai-dala-realm", RoleAdmin}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req = req.WithContext(context.WithValue(req.Context(), RolesKey, tt.roles))
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.status {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.status)
+			}
+		})
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := RequirePermission(PermManageTaxonomy)(next)
+
+	tests := []struct {
+		name   string
+		roles  []string
+		status int
+	}{
+		{"Visitor", []string{"default-roles-ai-dala-realm"}, http.StatusForbidden},
+		{"Content manager", []string{RoleContentManager}, http.StatusOK},
+		{"Admin", []string{RoleAdmin}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/tags", nil)
+			req = req.WithContext(context.WithValue(req.Context(), RolesKey, tt.roles))
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.status {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+var (
+	// ErrProviderUnavailable is returned when the OIDC provider cannot be reached
+	ErrProviderUnavailable = errors.New("identity provider unavailable")
+	// ErrTokenExpired is returned when the token expired longer than ClockSkew ago
+	ErrTokenExpired = errors.New("token is expired")
+	// ErrTokenNotYetValid is returned when the token becomes valid more than ClockSkew from now
+	ErrTokenNotYetValid = errors.New("token is not valid yet")
+)
+
+// VerifierConfig configures token verification against Keycloak
+type VerifierConfig struct {
+	// IssuerURL is the Keycloak realm URL, e.g. http://keycloak:8080/realms/ai-dala-realm
+	IssuerURL string
+	// ClientID selects the resource_access entry used for client roles
+	ClientID string
+	// Audience, when set, must be present in the token "aud" claim
+	Audience string
+	// ClockSkew is the tolerated difference between our clock and Keycloak's, in either
+	// direction: it applies to both the "exp" and "nbf" claims
+	ClockSkew time.Duration
+	// MaxRetries bounds discovery retries while Keycloak is unavailable
+	MaxRetries int
+	// RetryDelay is the initial backoff between retries, doubled on each attempt
+	RetryDelay time.Duration
+	// HTTPTimeout limits discovery and JWKS requests
+	HTTPTimeout time.Duration
+}
+
+// VerifierConfigFromEnv reads the verifier configuration from environment variables
+func VerifierConfigFromEnv() VerifierConfig {
+	cfg := VerifierConfig{
+		IssuerURL:   os.Getenv("KEYCLOAK_ISSUER"),
+		ClientID:    os.Getenv("KEYCLOAK_CLIENT_ID"),
+		Audience:    os.Getenv("KEYCLOAK_AUDIENCE"),
+		ClockSkew:   30 * time.Second,
+		MaxRetries:  3,
+		RetryDelay:  200 * time.Millisecond,
+		HTTPTimeout: 5 * time.Second,
+	}
+	if v := os.Getenv("AUTH_CLOCK_SKEW"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfg.ClockSkew = d
+		}
+	}
+	if v := os.Getenv("AUTH_DISCOVERY_RETRIES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.MaxRetries = n
+		}
+	}
+	return cfg
+}
+
+// Claims holds the identity extracted from a verified access token
+type Claims struct {
+	Subject string
+	Roles   []string
+}
+
+// Verifier validates Keycloak access tokens. It is constructed once at startup:
+// discovery runs once and is cached, and the underlying JWKS key set is cached
+// and refreshed automatically when a token is signed with an unknown key (key rotation).
+type Verifier struct {
+	cfg    VerifierConfig
+	client *http.Client
+
+	// mu guards the cached verifier and the discovery in flight; it is never held during
+	// discovery itself, so requests do not queue behind retries and backoff
+	mu          sync.Mutex
+	verifier    *oidc.IDTokenVerifier
+	discovering chan struct{}
+	discoverErr error
+}
+
+// NewVerifier creates a verifier. Discovery is deferred until Discover or the
+// first Verify call, so the API can start while Keycloak is still booting.
+func NewVerifier(cfg VerifierConfig) *Verifier {
+	if cfg.HTTPTimeout <= 0 {
+		cfg.HTTPTimeout = 5 * time.Second
+	}
+	return &Verifier{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.HTTPTimeout},
+	}
+}
+
+// Discover fetches the provider metadata, retrying with backoff up to MaxRetries times
+func (v *Verifier) Discover(ctx context.Context) error {
+	_, err := v.idTokenVerifier(ctx)
+	return err
+}
+
+// idTokenVerifier returns the cached verifier, running discovery when there is none yet.
+// Concurrent callers share a single discovery attempt and its result.
+func (v *Verifier) idTokenVerifier(ctx context.Context) (*oidc.IDTokenVerifier, error) {
+	v.mu.Lock()
+	if v.verifier != nil {
+		verifier := v.verifier
+		v.mu.Unlock()
+		return verifier, nil
+	}
+	if v.cfg.IssuerURL == "" {
+		v.mu.Unlock()
+		return nil, fmt.Errorf("%w: issuer is not configured", ErrProviderUnavailable)
+	}
+	if done := v.discovering; done != nil {
+		v.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %v", ErrProviderUnavailable, ctx.Err())
+		case <-done:
+		}
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		return v.verifier, v.discoverErr
+	}
+	done := make(chan struct{})
+	v.discovering = done
+	v.mu.Unlock()
+
+	verifier, err := v.discover(ctx)
+
+	v.mu.Lock()
+	v.verifier, v.discoverErr, v.discovering = verifier, err, nil
+	v.mu.Unlock()
+	close(done)
+	return verifier, err
+}
+
+// discover fetches the provider metadata, retrying with backoff up to MaxRetries times
+func (v *Verifier) discover(ctx context.Context) (*oidc.IDTokenVerifier, error) {
+	// The provider keeps this context for background JWKS refreshes,
+	// so it must not be tied to the lifetime of a single request.
+	providerCtx := oidc.ClientContext(context.Background(), v.client)
+
+	delay := v.cfg.RetryDelay
+	var lastErr error
+	for attempt := 0; attempt <= v.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			log.Printf("[AUTH] Retrying OIDC discovery in %s (attempt %d/%d): %v", delay, attempt, v.cfg.MaxRetries, lastErr)
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("%w: %v", ErrProviderUnavailable, ctx.Err())
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+
+		provider, err := oidc.NewProvider(providerCtx, v.cfg.IssuerURL)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		verifier := provider.Verifier(&oidc.Config{
+			ClientID: v.cfg.Audience,
+			// Access tokens have audience "account" rather than the client ID,
+			// so the audience is only checked when explicitly configured
+			SkipClientIDCheck: v.cfg.Audience == "",
+			// Expiry and not-before are checked by Verify with the configured skew
+			SkipExpiryCheck: true,
+		})
+		log.Printf("[AUTH] OIDC provider discovered for issuer: %s", v.cfg.IssuerURL)
+		return verifier, nil
+	}
+
+	log.Printf("[AUTH] Failed to discover OIDC provider %s: %v", v.cfg.IssuerURL, lastErr)
+	return nil, fmt.Errorf("%w: %v", ErrProviderUnavailable, lastErr)
+}
+
+// Verify checks the token signature, issuer, audience and validity period and returns its claims
+func (v *Verifier) Verify(ctx context.Context, rawToken string) (*Claims, error) {
+	verifier, err := v.idTokenVerifier(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	idToken, err := verifier.Verify(ctx, rawToken)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := struct {
+		Sub       string   `json:"sub"`
+		NotBefore *float64 `json:"nbf"`
+		tokenRoleClaims
+	}{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("invalid claims: %w", err)
+	}
+
+	now := time.Now()
+	if idToken.Expiry.IsZero() || now.After(idToken.Expiry.Add(v.cfg.ClockSkew)) {
+		return nil, ErrTokenExpired
+	}
+	if claims.NotBefore != nil {
+		notBefore := time.Unix(0, int64(*claims.NotBefore*float64(time.Second)))
+		if now.Add(v.cfg.ClockSkew).Before(notBefore) {
+			return nil, ErrTokenNotYetValid
+		}
+	}
+
+	return &Claims{
+		Subject: claims.Sub,
+		Roles:   claims.roles(v.cfg.ClientID),
+	}, nil
+}
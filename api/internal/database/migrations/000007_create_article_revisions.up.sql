@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS article_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    revision_number INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    category_id UUID,
+    editor_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (article_id, revision_number)
+);
+
+CREATE INDEX idx_article_revisions_article ON article_revisions(article_id);
+
+-- Record the current state of existing articles as their first revision
+INSERT INTO article_revisions (article_id, revision_number, title, body, category_id, editor_id, created_at)
+SELECT id, 1, title, body, category_id, author_id, updated_at
+FROM articles;
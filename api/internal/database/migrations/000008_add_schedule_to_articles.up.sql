@@ -0,0 +1,6 @@
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMP WITH TIME ZONE;
+
+-- Partial indexes keep the scheduler's due-item lookups cheap
+CREATE INDEX IF NOT EXISTS idx_articles_publish_at ON articles(publish_at) WHERE publish_at IS NOT NULL AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_articles_unpublish_at ON articles(unpublish_at) WHERE unpublish_at IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS article_review_notes;
+DROP TABLE IF EXISTS article_status_transitions;
+
+UPDATE articles SET status = 'DRAFT' WHERE status IN ('IN_REVIEW', 'CHANGES_REQUESTED', 'APPROVED');
+ALTER TABLE articles DROP CONSTRAINT IF EXISTS articles_status_check;
+ALTER TABLE articles ADD CONSTRAINT articles_status_check
+    CHECK (status IN ('DRAFT', 'PUBLISHED', 'ARCHIVED'));
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS news_tags;
+DROP TABLE IF EXISTS news;
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS news (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title TEXT NOT NULL,
+    slug TEXT NOT NULL,
+    summary TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL DEFAULT '',
+    image_url TEXT NOT NULL DEFAULT '',
+    category_id UUID REFERENCES categories(id),
+    author_id UUID,
+    status TEXT NOT NULL DEFAULT 'DRAFT' CHECK (status IN ('DRAFT', 'PUBLISHED', 'ARCHIVED')),
+    published_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT news_slug_key UNIQUE (slug)
+);
+
+CREATE INDEX idx_news_category ON news(category_id);
+CREATE INDEX idx_news_status_published ON news(status, published_at DESC);
+CREATE INDEX idx_news_deleted ON news(deleted_at);
+
+-- Many-to-Many: News <-> Tags
+CREATE TABLE IF NOT EXISTS news_tags (
+    news_id UUID REFERENCES news(id) ON DELETE CASCADE,
+    tag_id UUID REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (news_id, tag_id)
+);
+
+CREATE INDEX idx_news_tags_tag ON news_tags(tag_id);
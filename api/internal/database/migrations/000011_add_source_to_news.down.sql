@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_news_content_hash;
+DROP INDEX IF EXISTS idx_news_source_url;
+
+ALTER TABLE news DROP COLUMN IF EXISTS content_hash;
+ALTER TABLE news DROP COLUMN IF EXISTS source_url;
+ALTER TABLE news DROP COLUMN IF EXISTS source_name;
@@ -0,0 +1,8 @@
+-- Imported news keeps a reference to where it came from; source_url and
+-- content_hash are used to skip entries that were already ingested
+ALTER TABLE news ADD COLUMN source_name TEXT NOT NULL DEFAULT '';
+ALTER TABLE news ADD COLUMN source_url TEXT;
+ALTER TABLE news ADD COLUMN content_hash TEXT;
+
+CREATE UNIQUE INDEX idx_news_source_url ON news(source_url) WHERE source_url IS NOT NULL;
+CREATE UNIQUE INDEX idx_news_content_hash ON news(content_hash) WHERE content_hash IS NOT NULL;
@@ -0,0 +1,6 @@
+ALTER TABLE comments ADD COLUMN parent_id UUID REFERENCES comments(id) ON DELETE CASCADE;
+ALTER TABLE comments ADD COLUMN depth INTEGER NOT NULL DEFAULT 0;
+
+-- Keyset pagination of top-level comments (newest first) and of replies (oldest first)
+CREATE INDEX idx_comments_article_top_level ON comments(article_id, created_at DESC, id DESC) WHERE parent_id IS NULL;
+CREATE INDEX idx_comments_parent ON comments(parent_id, created_at, id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS comment_edits;
+ALTER TABLE comments DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE comments DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,13 @@
+-- Deleted comments stay in place so replies keep their parent
+ALTER TABLE comments ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE comments ADD COLUMN deleted_by UUID;
+
+CREATE TABLE IF NOT EXISTS comment_edits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    comment_id UUID NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    editor_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_comment_edits_comment_id ON comment_edits(comment_id, created_at);
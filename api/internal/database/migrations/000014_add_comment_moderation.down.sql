@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_comments_user_created;
+DROP INDEX IF EXISTS idx_comments_status;
+ALTER TABLE comments DROP COLUMN IF EXISTS moderated_at;
+ALTER TABLE comments DROP COLUMN IF EXISTS moderated_by;
+ALTER TABLE comments DROP COLUMN IF EXISTS moderation_reason;
+ALTER TABLE comments DROP COLUMN IF EXISTS status;
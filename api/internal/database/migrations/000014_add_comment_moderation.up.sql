@@ -0,0 +1,9 @@
+-- Existing comments were visible before moderation existed, so they start APPROVED
+ALTER TABLE comments ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'APPROVED'
+    CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED', 'SPAM'));
+ALTER TABLE comments ADD COLUMN moderation_reason TEXT;
+ALTER TABLE comments ADD COLUMN moderated_by UUID;
+ALTER TABLE comments ADD COLUMN moderated_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_comments_status ON comments(status, created_at);
+CREATE INDEX idx_comments_user_created ON comments(user_id, created_at);
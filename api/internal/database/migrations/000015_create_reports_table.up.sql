@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    target_type VARCHAR(20) NOT NULL CHECK (target_type IN ('article', 'comment')),
+    target_id UUID NOT NULL,
+    reporter_id UUID NOT NULL,
+    reason_code VARCHAR(30) NOT NULL
+        CHECK (reason_code IN ('spam', 'abuse', 'harassment', 'misinformation', 'off_topic', 'other')),
+    details TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'DISMISSED', 'RESOLVED')),
+    resolution VARCHAR(20) CHECK (resolution IN ('dismiss', 'hide', 'delete')),
+    resolved_by UUID,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    -- A reader can report a target only once
+    CONSTRAINT reports_target_reporter_key UNIQUE (target_type, target_id, reporter_id)
+);
+
+CREATE INDEX idx_reports_open_targets ON reports(target_type, target_id) WHERE status = 'OPEN';
+CREATE INDEX idx_reports_status_created ON reports(status, created_at);
@@ -0,0 +1,12 @@
+DROP TRIGGER IF EXISTS article_comments_count_trigger ON comments;
+DROP FUNCTION IF EXISTS article_comments_count();
+DROP TRIGGER IF EXISTS article_likes_count_trigger ON article_likes;
+DROP FUNCTION IF EXISTS article_likes_count();
+DROP TRIGGER IF EXISTS articles_search_update ON articles;
+CREATE TRIGGER articles_search_update
+  BEFORE INSERT OR UPDATE ON articles
+  FOR EACH ROW EXECUTE FUNCTION
+    tsvector_update_trigger(search_vector, 'pg_catalog.english', title, body);
+ALTER TABLE articles DROP COLUMN IF EXISTS comments_count;
+ALTER TABLE articles DROP COLUMN IF EXISTS dislikes_count;
+ALTER TABLE articles DROP COLUMN IF EXISTS likes_count;
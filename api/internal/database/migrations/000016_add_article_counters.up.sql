@@ -0,0 +1,55 @@
+ALTER TABLE articles ADD COLUMN likes_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE articles ADD COLUMN dislikes_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE articles ADD COLUMN comments_count INTEGER NOT NULL DEFAULT 0;
+
+UPDATE articles a SET
+    likes_count = (SELECT COUNT(*) FROM article_likes l WHERE l.article_id = a.id AND l.is_like),
+    dislikes_count = (SELECT COUNT(*) FROM article_likes l WHERE l.article_id = a.id AND NOT l.is_like),
+    comments_count = (SELECT COUNT(*) FROM comments c WHERE c.article_id = a.id AND c.status = 'APPROVED' AND c.deleted_at IS NULL);
+
+-- Counter updates must not rebuild the search vector
+DROP TRIGGER IF EXISTS articles_search_update ON articles;
+CREATE TRIGGER articles_search_update
+  BEFORE INSERT OR UPDATE OF title, body ON articles
+  FOR EACH ROW EXECUTE FUNCTION
+    tsvector_update_trigger(search_vector, 'pg_catalog.english', title, body);
+
+-- Keep likes_count and dislikes_count in step with article_likes
+CREATE OR REPLACE FUNCTION article_likes_count() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE articles SET
+            likes_count = likes_count - CASE WHEN OLD.is_like THEN 1 ELSE 0 END,
+            dislikes_count = dislikes_count - CASE WHEN OLD.is_like THEN 0 ELSE 1 END
+        WHERE id = OLD.article_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        UPDATE articles SET
+            likes_count = likes_count + CASE WHEN NEW.is_like THEN 1 ELSE 0 END,
+            dislikes_count = dislikes_count + CASE WHEN NEW.is_like THEN 0 ELSE 1 END
+        WHERE id = NEW.article_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER article_likes_count_trigger
+    AFTER INSERT OR UPDATE OF is_like OR DELETE ON article_likes
+    FOR EACH ROW EXECUTE FUNCTION article_likes_count();
+
+-- comments_count counts the comments readers can see: approved and not deleted
+CREATE OR REPLACE FUNCTION article_comments_count() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.status = 'APPROVED' AND OLD.deleted_at IS NULL THEN
+        UPDATE articles SET comments_count = comments_count - 1 WHERE id = OLD.article_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.status = 'APPROVED' AND NEW.deleted_at IS NULL THEN
+        UPDATE articles SET comments_count = comments_count + 1 WHERE id = NEW.article_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER article_comments_count_trigger
+    AFTER INSERT OR UPDATE OF status, deleted_at OR DELETE ON comments
+    FOR EACH ROW EXECUTE FUNCTION article_comments_count();
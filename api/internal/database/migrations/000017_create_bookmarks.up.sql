@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS bookmark_collections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT bookmark_collections_user_name_key UNIQUE (user_id, name)
+);
+
+-- One bookmark per reader and article, optionally filed in one of the reader's collections
+CREATE TABLE IF NOT EXISTS bookmarks (
+    user_id UUID NOT NULL,
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    collection_id UUID REFERENCES bookmark_collections(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, article_id)
+);
+
+CREATE INDEX idx_bookmarks_user_created ON bookmarks(user_id, created_at DESC);
+CREATE INDEX idx_bookmarks_collection ON bookmarks(collection_id) WHERE collection_id IS NOT NULL;
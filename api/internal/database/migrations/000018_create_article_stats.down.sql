@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS article_daily_stats;
+DROP TABLE IF EXISTS article_views;
+DROP TABLE IF EXISTS article_view_salts;
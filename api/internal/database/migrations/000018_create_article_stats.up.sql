@@ -0,0 +1,44 @@
+-- One random salt per UTC day for hashing visitors; salts of past days are deleted,
+-- so stored hashes cannot be linked to an address once the day is over
+CREATE TABLE IF NOT EXISTS article_view_salts (
+    day DATE PRIMARY KEY,
+    salt BYTEA NOT NULL
+);
+
+-- Unique visitors per article and day, kept only until the day is rolled up
+CREATE TABLE IF NOT EXISTS article_views (
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    visitor_hash CHAR(64) NOT NULL,
+    PRIMARY KEY (article_id, day, visitor_hash)
+);
+
+CREATE INDEX idx_article_views_day ON article_views(day);
+
+CREATE TABLE IF NOT EXISTS article_daily_stats (
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    views INTEGER NOT NULL DEFAULT 0,
+    likes INTEGER NOT NULL DEFAULT 0,
+    comments INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (article_id, day)
+);
+
+CREATE INDEX idx_article_daily_stats_day ON article_daily_stats(day);
+
+-- Backfill likes and comments from before views were tracked
+INSERT INTO article_daily_stats (article_id, day, likes, comments)
+SELECT article_id, day, SUM(likes), SUM(comments)
+FROM (
+    SELECT article_id, (created_at AT TIME ZONE 'UTC')::date AS day, COUNT(*) AS likes, 0 AS comments
+    FROM article_likes
+    WHERE is_like
+    GROUP BY 1, 2
+    UNION ALL
+    SELECT article_id, (created_at AT TIME ZONE 'UTC')::date, 0, COUNT(*)
+    FROM comments
+    WHERE status = 'APPROVED' AND deleted_at IS NULL
+    GROUP BY 1, 2
+) activity
+GROUP BY article_id, day;
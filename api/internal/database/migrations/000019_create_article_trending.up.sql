@@ -0,0 +1,11 @@
+-- Time-decayed activity score of published articles per ranking period (24h, 7d, 30d),
+-- recomputed by the stats worker; articles without recent activity have no row
+CREATE TABLE IF NOT EXISTS article_trending (
+    period VARCHAR(8) NOT NULL,
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    score DOUBLE PRECISION NOT NULL,
+    refreshed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (period, article_id)
+);
+
+CREATE INDEX idx_article_trending_period_score ON article_trending(period, score DESC);
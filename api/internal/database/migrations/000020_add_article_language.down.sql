@@ -0,0 +1,15 @@
+DROP TRIGGER IF EXISTS articles_search_update ON articles;
+CREATE TRIGGER articles_search_update
+  BEFORE INSERT OR UPDATE OF title, body ON articles
+  FOR EACH ROW EXECUTE FUNCTION
+    tsvector_update_trigger(search_vector, 'pg_catalog.english', title, body);
+
+DROP FUNCTION IF EXISTS articles_search_vector_update();
+DROP FUNCTION IF EXISTS article_search_config(TEXT);
+DROP TEXT SEARCH CONFIGURATION IF EXISTS kazakh;
+
+DROP INDEX IF EXISTS idx_articles_language;
+ALTER TABLE articles DROP CONSTRAINT IF EXISTS articles_language_check;
+ALTER TABLE articles DROP COLUMN IF EXISTS language;
+
+UPDATE articles SET search_vector = to_tsvector('pg_catalog.english', COALESCE(title, '') || ' ' || COALESCE(body, ''));
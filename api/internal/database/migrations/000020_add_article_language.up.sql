@@ -0,0 +1,38 @@
+-- Articles are written in English, Russian or Kazakh
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS language VARCHAR(2) NOT NULL DEFAULT 'en';
+ALTER TABLE articles ADD CONSTRAINT articles_language_check CHECK (language IN ('en', 'ru', 'kk'));
+CREATE INDEX idx_articles_language ON articles(language);
+
+-- PostgreSQL has no Kazakh stemmer: index Kazakh words as they are, ignoring diacritics
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE TEXT SEARCH CONFIGURATION kazakh (COPY = simple);
+ALTER TEXT SEARCH CONFIGURATION kazakh
+    ALTER MAPPING FOR asciiword, asciihword, hword_asciipart, word, hword, hword_part
+    WITH unaccent, simple;
+
+-- Text search configuration used for an article language
+CREATE OR REPLACE FUNCTION article_search_config(lang TEXT) RETURNS regconfig AS $$
+    SELECT CASE lang
+        WHEN 'ru' THEN 'russian'::regconfig
+        WHEN 'kk' THEN 'kazakh'::regconfig
+        ELSE 'english'::regconfig
+    END;
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION articles_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := to_tsvector(
+        article_search_config(NEW.language),
+        COALESCE(NEW.title, '') || ' ' || COALESCE(NEW.body, '')
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS articles_search_update ON articles;
+CREATE TRIGGER articles_search_update
+  BEFORE INSERT OR UPDATE OF title, body, language ON articles
+  FOR EACH ROW EXECUTE FUNCTION articles_search_vector_update();
+
+UPDATE articles
+SET search_vector = to_tsvector(article_search_config(language), COALESCE(title, '') || ' ' || COALESCE(body, ''));
@@ -0,0 +1,17 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Typo-tolerant title completion
+CREATE INDEX IF NOT EXISTS idx_articles_title_trgm ON articles USING GIN (title gin_trgm_ops);
+
+-- Words used in published articles, for "did you mean" corrections; refreshed by the stats worker
+CREATE MATERIALIZED VIEW IF NOT EXISTS search_terms AS
+SELECT word, ndoc
+FROM ts_stat($$
+    SELECT to_tsvector('simple', title || ' ' || body)
+    FROM articles
+    WHERE status = 'PUBLISHED' AND deleted_at IS NULL
+$$)
+WHERE length(word) >= 3 AND word !~ '^[0-9]+$';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_search_terms_word ON search_terms(word);
+CREATE INDEX IF NOT EXISTS idx_search_terms_word_trgm ON search_terms USING GIN (word gin_trgm_ops);
@@ -0,0 +1,12 @@
+CREATE OR REPLACE FUNCTION articles_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := to_tsvector(
+        article_search_config(NEW.language),
+        COALESCE(NEW.title, '') || ' ' || COALESCE(NEW.body, '')
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+UPDATE articles
+SET search_vector = to_tsvector(article_search_config(language), COALESCE(title, '') || ' ' || COALESCE(body, ''));
@@ -0,0 +1,14 @@
+-- Weight titles (A) above bodies (B) so title matches rank higher and can be searched alone
+CREATE OR REPLACE FUNCTION articles_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector(article_search_config(NEW.language), COALESCE(NEW.title, '')), 'A') ||
+        setweight(to_tsvector(article_search_config(NEW.language), COALESCE(NEW.body, '')), 'B');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+UPDATE articles
+SET search_vector =
+    setweight(to_tsvector(article_search_config(language), COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector(article_search_config(language), COALESCE(body, '')), 'B');
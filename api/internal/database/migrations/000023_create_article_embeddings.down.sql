@@ -0,0 +1,2 @@
+DROP FUNCTION IF EXISTS cosine_similarity(REAL[], REAL[]);
+DROP TABLE IF EXISTS article_embeddings;
@@ -0,0 +1,19 @@
+-- Embeddings of article chunks for semantic search. Vectors are stored as arrays because
+-- pgvector is not available in the stock PostgreSQL image; model identifies the embedding
+-- space so vectors are recomputed when the model changes.
+CREATE TABLE IF NOT EXISTS article_embeddings (
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    chunk INTEGER NOT NULL,
+    model VARCHAR(100) NOT NULL,
+    content TEXT NOT NULL,
+    embedding REAL[] NOT NULL,
+    embedded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (article_id, chunk)
+);
+
+CREATE INDEX idx_article_embeddings_model ON article_embeddings(model, article_id);
+
+CREATE OR REPLACE FUNCTION cosine_similarity(a REAL[], b REAL[]) RETURNS DOUBLE PRECISION AS $$
+    SELECT COALESCE(SUM(x::float8 * y) / NULLIF(SQRT(SUM(x::float8 * x)) * SQRT(SUM(y::float8 * y)), 0), 0)
+    FROM unnest(a, b) AS v(x, y)
+$$ LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE;
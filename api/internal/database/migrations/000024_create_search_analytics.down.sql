@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS search_daily_stats;
+DROP TABLE IF EXISTS search_clicks;
+DROP TABLE IF EXISTS search_queries;
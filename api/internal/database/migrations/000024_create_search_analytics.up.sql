@@ -0,0 +1,33 @@
+-- Searches run by readers. Queries are normalized with personal data masked, and readers
+-- are only identified by a hash salted daily, used to count a repeated search once.
+CREATE TABLE IF NOT EXISTS search_queries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    query VARCHAR(200) NOT NULL,
+    results INTEGER NOT NULL,
+    mode VARCHAR(10) NOT NULL DEFAULT 'keyword',
+    visitor_hash CHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_search_queries_created ON search_queries(created_at);
+CREATE INDEX idx_search_queries_visitor ON search_queries(visitor_hash, query, created_at);
+
+-- Results opened from a search, once per search and article
+CREATE TABLE IF NOT EXISTS search_clicks (
+    search_id UUID NOT NULL REFERENCES search_queries(id) ON DELETE CASCADE,
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (search_id, article_id)
+);
+
+CREATE TABLE IF NOT EXISTS search_daily_stats (
+    day DATE NOT NULL,
+    query VARCHAR(200) NOT NULL,
+    searches INTEGER NOT NULL DEFAULT 0,
+    zero_results INTEGER NOT NULL DEFAULT 0,
+    clicked_searches INTEGER NOT NULL DEFAULT 0,
+    clicks INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (day, query)
+);
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS reports_open_target_reporter_key;
+
+-- Keep the latest report of each reader on a target
+DELETE FROM reports r
+USING reports newer
+WHERE newer.target_type = r.target_type AND newer.target_id = r.target_id
+  AND newer.reporter_id = r.reporter_id AND newer.created_at > r.created_at;
+
+ALTER TABLE reports ADD CONSTRAINT reports_target_reporter_key UNIQUE (target_type, target_id, reporter_id);
+
+ALTER TABLE comments DROP COLUMN IF EXISTS hidden_by_reports;
+ALTER TABLE articles DROP COLUMN IF EXISTS hidden_by_reports;
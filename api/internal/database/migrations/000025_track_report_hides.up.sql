@@ -0,0 +1,9 @@
+-- Set only when the report threshold hid the target, so dismissing the reports restores
+-- just those; any other status change clears it
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS hidden_by_reports BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS hidden_by_reports BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- A reader can report a target again once their earlier report is closed
+ALTER TABLE reports DROP CONSTRAINT IF EXISTS reports_target_reporter_key;
+CREATE UNIQUE INDEX IF NOT EXISTS reports_open_target_reporter_key
+    ON reports(target_type, target_id, reporter_id) WHERE status = 'OPEN';
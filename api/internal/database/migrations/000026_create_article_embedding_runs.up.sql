@@ -0,0 +1,13 @@
+-- When each article was last embedded and with which model. Articles without any text to
+-- embed have no chunks, so this is what tells the indexer they are up to date.
+CREATE TABLE IF NOT EXISTS article_embedding_runs (
+    article_id UUID PRIMARY KEY REFERENCES articles(id) ON DELETE CASCADE,
+    model VARCHAR(100) NOT NULL,
+    embedded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO article_embedding_runs (article_id, model, embedded_at)
+SELECT DISTINCT ON (article_id) article_id, model, embedded_at
+FROM article_embeddings
+ORDER BY article_id, embedded_at
+ON CONFLICT (article_id) DO NOTHING;
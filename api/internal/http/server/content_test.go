@@ -2,16 +2,128 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/ai-dala/api/internal/modules/articles"
+	"github.com/ai-dala/api/internal/modules/news"
 )
 
-func TestNewsListHandler(t *testing.T) {
-	// Content handlers don't use auth yet, so we can pass nil or a mock
-	srv := NewServer(nil, nil, nil, nil, nil)
+// fakeNews is an in-memory NewsSource holding published items newest first
+type fakeNews struct {
+	items []news.News
+	tags  map[string][]string
+}
+
+func newFakeNews(n int) *fakeNews {
+	categories := []string{"OpenAI", "Tools", "Market", "AI-Dala updates"}
+	f := &fakeNews{tags: map[string][]string{}}
+	now := time.Now()
+	for i := 0; i < n; i++ {
+		published := now.Add(time.Duration(-i) * 24 * time.Hour)
+		id := fmt.Sprintf("news-%d", i)
+		f.items = append(f.items, news.News{
+			ID:          id,
+			Slug:        fmt.Sprintf("ai-dala-master-class-%d", i),
+			Title:       fmt.Sprintf("AI-Dala launches master-class series #%d", i+1),
+			Status:      news.StatusPublished,
+			PublishedAt: &published,
+		})
+		f.tags[id] = []string{categories[i%len(categories)]}
+	}
+	return f
+}
+
+func (f *fakeNews) ListPublished(category string, limit, offset int) ([]news.News, int, error) {
+	var matched []news.News
+	for _, item := range f.items {
+		if category == "" || f.tags[item.ID][0] == category {
+			matched = append(matched, item)
+		}
+	}
+	total := len(matched)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return matched[offset:end], total, nil
+}
+
+func (f *fakeNews) GetPublishedBySlug(slug string) (*news.News, error) {
+	for i := range f.items {
+		if f.items[i].Slug == slug {
+			return &f.items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeNews) GetTags(id string) ([]string, error) {
+	return f.tags[id], nil
+}
+
+// fakeArticles is an in-memory ArticleSource that records the last filters it was asked for
+type fakeArticles struct {
+	items          []articles.Article
+	lastCategoryID string
+	lastTags       []string
+}
+
+func (f *fakeArticles) GetPublicArticles(categoryID string, tags []string, limit, offset int) ([]articles.Article, int, error) {
+	f.lastCategoryID, f.lastTags = categoryID, tags
+	var published []articles.Article
+	for _, a := range f.items {
+		if a.Status == articles.StatusPublished {
+			published = append(published, a)
+		}
+	}
+	return published, len(published), nil
+}
+
+func (f *fakeArticles) GetPublishedBySlug(slug string) (*articles.Article, error) {
+	for i := range f.items {
+		if f.items[i].Slug == slug && f.items[i].Status == articles.StatusPublished {
+			return &f.items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeArticles) GetTags(articleID string) ([]string, error) {
+	return []string{"tutorial"}, nil
+}
+
+func newFakeArticles() *fakeArticles {
+	published := time.Date(2025, 11, 20, 10, 0, 0, 0, time.UTC)
+	return &fakeArticles{items: []articles.Article{
+		{ID: "article-001", Slug: "getting-started", Title: "Getting Started", Body: "# Getting Started\n\nLearn **how**.", Status: articles.StatusPublished, PublishedAt: &published, UpdatedAt: published.Add(time.Hour)},
+		{ID: "article-002", Slug: "draft-article", Title: "Draft", Status: articles.StatusDraft},
+	}}
+}
+
+func newContentMux() *http.ServeMux {
+	return newContentMuxWith(newFakeArticles())
+}
+
+func newContentMuxWith(source *fakeArticles) *http.ServeMux {
+	srv := NewServer(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Content{
+		News:     newFakeNews(32),
+		Articles: source,
+		SiteURL:  "https://ai-dala.example/",
+	})
 	mux := http.NewServeMux()
 	srv.RegisterRoutes(mux)
+	return mux
+}
+
+func TestNewsListHandler(t *testing.T) {
+	mux := newContentMux()
 
 	t.Run("Default query", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/api/content/news", nil)
@@ -34,6 +146,12 @@ func TestNewsListHandler(t *testing.T) {
 		if resp.Page != 1 {
 			t.Errorf("expected page 1, got %d", resp.Page)
 		}
+		if resp.Total != 32 {
+			t.Errorf("expected total 32, got %d", resp.Total)
+		}
+		if resp.Items[0].URL != "/news/ai-dala-master-class-0" {
+			t.Errorf("unexpected url %q", resp.Items[0].URL)
+		}
 	})
 
 	t.Run("Pagination", func(t *testing.T) {
@@ -57,6 +175,9 @@ func TestNewsListHandler(t *testing.T) {
 		if resp.Page != 2 {
 			t.Errorf("expected page 2, got %d", resp.Page)
 		}
+		if resp.Items[0].Slug != "ai-dala-master-class-5" {
+			t.Errorf("expected page 2 to start at item 5, got %s", resp.Items[0].Slug)
+		}
 	})
 
 	t.Run("Filtering", func(t *testing.T) {
@@ -74,6 +195,9 @@ func TestNewsListHandler(t *testing.T) {
 			t.Fatalf("failed to decode response: %v", err)
 		}
 
+		if len(resp.Items) == 0 {
+			t.Fatal("expected filtered items")
+		}
 		for _, item := range resp.Items {
 			found := false
 			for _, tag := range item.Tags {
@@ -101,12 +225,9 @@ func TestNewsListHandler(t *testing.T) {
 }
 
 func TestNewsDetailHandler(t *testing.T) {
-	srv := NewServer(nil, nil, nil, nil, nil)
-	mux := http.NewServeMux()
-	srv.RegisterRoutes(mux)
+	mux := newContentMux()
 
 	t.Run("Valid Slug", func(t *testing.T) {
-		// We know "ai-dala-master-class-0" should exist from the seed
 		slug := "ai-dala-master-class-0"
 		req := httptest.NewRequest("GET", "/api/content/news/"+slug, nil)
 		rr := httptest.NewRecorder()
@@ -125,6 +246,9 @@ func TestNewsDetailHandler(t *testing.T) {
 		if item.Slug != slug {
 			t.Errorf("expected slug %s, got %s", slug, item.Slug)
 		}
+		if item.Image != defaultContentImage {
+			t.Errorf("expected default image, got %q", item.Image)
+		}
 	})
 
 	t.Run("Invalid Slug", func(t *testing.T) {
@@ -138,3 +262,54 @@ func TestNewsDetailHandler(t *testing.T) {
 		}
 	})
 }
+
+func TestArticlesContentHandlers(t *testing.T) {
+	mux := newContentMux()
+
+	t.Run("List", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/content/articles", nil)
+		rr := httptest.NewRecorder()
+
+		mux.ServeHTTP(rr, req)
+
+		var resp articleListResponse
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Items[0].URL != "/articles/getting-started" {
+			t.Errorf("unexpected url %q", resp.Items[0].URL)
+		}
+		if resp.Items[0].PublishedAt != "2025-11-20T10:00:00Z" {
+			t.Errorf("unexpected published_at %q", resp.Items[0].PublishedAt)
+		}
+	})
+
+	t.Run("Detail", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/content/articles/getting-started", nil)
+		rr := httptest.NewRecorder()
+
+		mux.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+		}
+		var item articleItem
+		if err := json.NewDecoder(rr.Body).Decode(&item); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if item.Summary != "Getting Started\n\nLearn how." {
+			t.Errorf("unexpected summary %q", item.Summary)
+		}
+	})
+
+	t.Run("Unpublished", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/content/articles/draft-article", nil)
+		rr := httptest.NewRecorder()
+
+		mux.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusNotFound)
+		}
+	})
+}
@@ -0,0 +1,425 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// feedSize is the number of most recent items included in a syndication feed
+const feedSize = 50
+
+// Syndication formats served under /feeds
+const (
+	feedFormatRSS  = "rss"
+	feedFormatAtom = "atom"
+	feedFormatJSON = "json"
+)
+
+var feedContentTypes = map[string]string{
+	feedFormatRSS:  "application/rss+xml; charset=utf-8",
+	feedFormatAtom: "application/atom+xml; charset=utf-8",
+	feedFormatJSON: "application/feed+json; charset=utf-8",
+}
+
+var errInvalidFeedQuery = errors.New("category_id must be a UUID")
+
+// feedMeta describes a feed as a whole
+type feedMeta struct {
+	Title       string
+	Description string
+	HomeURL     string
+	SelfURL     string
+}
+
+// feedEntry is a format-independent feed item
+type feedEntry struct {
+	ID        string
+	Title     string
+	URL       string
+	Summary   string
+	Image     string
+	Published time.Time
+	Updated   time.Time
+	Tags      []string
+}
+
+// registerFeedRoutes registers /feeds/{articles,news}.{rss,atom,json}
+func (s *Server) registerFeedRoutes(mux *http.ServeMux) {
+	for _, format := range []string{feedFormatRSS, feedFormatAtom, feedFormatJSON} {
+		if s.content.Articles != nil {
+			mux.HandleFunc("GET /feeds/articles."+format, s.feedHandler(format, s.articleFeed))
+		}
+		if s.content.News != nil {
+			mux.HandleFunc("GET /feeds/news."+format, s.feedHandler(format, s.newsFeed))
+		}
+	}
+}
+
+// articleFeed builds the article feed; ?category_id= and ?tags= narrow it down
+func (s *Server) articleFeed(r *http.Request) (feedMeta, []feedEntry, error) {
+	q := r.URL.Query()
+	var tags []string
+	for _, value := range q["tags"] {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	categoryID := q.Get("category_id")
+	if categoryID != "" {
+		if _, err := uuid.Parse(categoryID); err != nil {
+			return feedMeta{}, nil, errInvalidFeedQuery
+		}
+	}
+
+	list, _, err := s.content.Articles.GetPublicArticles(categoryID, tags, feedSize, 0)
+	if err != nil {
+		return feedMeta{}, nil, err
+	}
+
+	entries := make([]feedEntry, 0, len(list))
+	for i := range list {
+		a := &list[i]
+		articleTags, _ := s.content.Articles.GetTags(a.ID)
+		published := a.CreatedAt
+		if a.PublishedAt != nil {
+			published = *a.PublishedAt
+		}
+		entries = append(entries, feedEntry{
+			ID:        a.ID,
+			Title:     a.Title,
+			URL:       s.siteURL("/articles/" + a.Slug),
+			Summary:   a.Body,
+			Published: published,
+			Updated:   a.UpdatedAt,
+			Tags:      articleTags,
+		})
+	}
+
+	return feedMeta{
+		Title:       "AI-Dala articles",
+		Description: "Latest articles published on AI-Dala",
+		HomeURL:     s.siteURL("/articles"),
+		SelfURL:     s.siteURL(r.URL.RequestURI()),
+	}, entries, nil
+}
+
+// newsFeed builds the news feed; ?category= narrows it to a category or tag code
+func (s *Server) newsFeed(r *http.Request) (feedMeta, []feedEntry, error) {
+	items, _, err := s.content.News.ListPublished(r.URL.Query().Get("category"), feedSize, 0)
+	if err != nil {
+		return feedMeta{}, nil, err
+	}
+
+	entries := make([]feedEntry, 0, len(items))
+	for i := range items {
+		n := &items[i]
+		newsTags, _ := s.content.News.GetTags(n.ID)
+		published := n.CreatedAt
+		if n.PublishedAt != nil {
+			published = *n.PublishedAt
+		}
+		entries = append(entries, feedEntry{
+			ID:        n.ID,
+			Title:     n.Title,
+			URL:       s.siteURL("/news/" + n.Slug),
+			Summary:   n.Summary,
+			Image:     s.absoluteURL(n.ImageURL),
+			Published: published,
+			Updated:   n.UpdatedAt,
+			Tags:      newsTags,
+		})
+	}
+
+	return feedMeta{
+		Title:       "AI-Dala news",
+		Description: "Curated AI news from AI-Dala",
+		HomeURL:     s.siteURL("/news"),
+		SelfURL:     s.siteURL(r.URL.RequestURI()),
+	}, entries, nil
+}
+
+// feedHandler renders a feed in the given format. The ETag is derived from the
+// rendered document and Last-Modified from the most recently updated entry, so
+// http.ServeContent can answer conditional requests with 304 Not Modified.
+func (s *Server) feedHandler(format string, build func(*http.Request) (feedMeta, []feedEntry, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		meta, entries, err := build(r)
+		if errors.Is(err, errInvalidFeedQuery) {
+			writeJSON(w, http.StatusBadRequest, errorResponse{ErrorCode: "INVALID_QUERY", Message: err.Error()})
+			return
+		}
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{ErrorCode: "INTERNAL_ERROR", Message: err.Error()})
+			return
+		}
+
+		var lastModified time.Time
+		for _, e := range entries {
+			if e.Updated.After(lastModified) {
+				lastModified = e.Updated
+			}
+			if e.Published.After(lastModified) {
+				lastModified = e.Published
+			}
+		}
+
+		var body []byte
+		switch format {
+		case feedFormatRSS:
+			body, err = renderRSS(meta, entries, lastModified)
+		case feedFormatAtom:
+			body, err = renderAtom(meta, entries, lastModified)
+		default:
+			body, err = renderJSONFeed(meta, entries)
+		}
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{ErrorCode: "INTERNAL_ERROR", Message: err.Error()})
+			return
+		}
+
+		sum := sha256.Sum256(body)
+		w.Header().Set("Content-Type", feedContentTypes[format])
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		http.ServeContent(w, r, "", lastModified, bytes.NewReader(body))
+	}
+}
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	SelfLink      rssLink   `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	Description string        `xml:"description"`
+	PubDate     string        `xml:"pubDate"`
+	Categories  []string      `xml:"category"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length int    `xml:"length,attr"`
+}
+
+func renderRSS(meta feedMeta, entries []feedEntry, updated time.Time) ([]byte, error) {
+	doc := rssDocument{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:       meta.Title,
+			Link:        meta.HomeURL,
+			Description: meta.Description,
+			SelfLink:    rssLink{Href: meta.SelfURL, Rel: "self", Type: "application/rss+xml"},
+		},
+	}
+	if !updated.IsZero() {
+		doc.Channel.LastBuildDate = updated.UTC().Format(time.RFC1123Z)
+	}
+	for _, e := range entries {
+		item := rssItem{
+			Title:       e.Title,
+			Link:        e.URL,
+			GUID:        rssGUID{IsPermaLink: true, Value: e.URL},
+			Description: e.Summary,
+			PubDate:     e.Published.UTC().Format(time.RFC1123Z),
+			Categories:  e.Tags,
+		}
+		if e.Image != "" {
+			item.Enclosure = &rssEnclosure{URL: e.Image, Type: imageType(e.Image)}
+		}
+		doc.Channel.Items = append(doc.Channel.Items, item)
+	}
+	return marshalXML(doc)
+}
+
+type atomDocument struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Summary    string         `xml:"summary,omitempty"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+func renderAtom(meta feedMeta, entries []feedEntry, updated time.Time) ([]byte, error) {
+	if updated.IsZero() {
+		updated = time.Unix(0, 0)
+	}
+	doc := atomDocument{
+		Title:   meta.Title,
+		ID:      meta.SelfURL,
+		Updated: updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: meta.HomeURL, Rel: "alternate", Type: "text/html"},
+			{Href: meta.SelfURL, Rel: "self", Type: "application/atom+xml"},
+		},
+	}
+	for _, e := range entries {
+		entry := atomEntry{
+			Title:     e.Title,
+			ID:        e.URL,
+			Links:     []atomLink{{Href: e.URL, Rel: "alternate", Type: "text/html"}},
+			Published: e.Published.UTC().Format(time.RFC3339),
+			Updated:   latest(e.Published, e.Updated).UTC().Format(time.RFC3339),
+			Summary:   e.Summary,
+		}
+		if e.Image != "" {
+			entry.Links = append(entry.Links, atomLink{Href: e.Image, Rel: "enclosure", Type: imageType(e.Image)})
+		}
+		for _, tag := range e.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: tag})
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+	return marshalXML(doc)
+}
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	HomePageURL string         `json:"home_page_url"`
+	FeedURL     string         `json:"feed_url"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string   `json:"id"`
+	URL           string   `json:"url"`
+	Title         string   `json:"title"`
+	ContentText   string   `json:"content_text"`
+	Summary       string   `json:"summary,omitempty"`
+	Image         string   `json:"image,omitempty"`
+	DatePublished string   `json:"date_published"`
+	DateModified  string   `json:"date_modified,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+func renderJSONFeed(meta feedMeta, entries []feedEntry) ([]byte, error) {
+	doc := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       meta.Title,
+		Description: meta.Description,
+		HomePageURL: meta.HomeURL,
+		FeedURL:     meta.SelfURL,
+		Items:       make([]jsonFeedItem, 0, len(entries)),
+	}
+	for _, e := range entries {
+		item := jsonFeedItem{
+			ID:            e.URL,
+			URL:           e.URL,
+			Title:         e.Title,
+			ContentText:   e.Summary,
+			Summary:       e.Summary,
+			Image:         e.Image,
+			DatePublished: e.Published.UTC().Format(time.RFC3339),
+			Tags:          e.Tags,
+		}
+		if !e.Updated.IsZero() {
+			item.DateModified = e.Updated.UTC().Format(time.RFC3339)
+		}
+		doc.Items = append(doc.Items, item)
+	}
+	return json.Marshal(doc)
+}
+
+func marshalXML(doc any) ([]byte, error) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// siteURL makes a path absolute against the public site URL
+func (s *Server) siteURL(path string) string {
+	return strings.TrimRight(s.content.SiteURL, "/") + path
+}
+
+// absoluteURL makes a root-relative link absolute against the public site URL
+func (s *Server) absoluteURL(link string) string {
+	if strings.HasPrefix(link, "/") && !strings.HasPrefix(link, "//") {
+		return s.siteURL(link)
+	}
+	return link
+}
+
+func imageType(url string) string {
+	lower := strings.ToLower(url)
+	switch {
+	case strings.HasSuffix(lower, ".png"):
+		return "image/png"
+	case strings.HasSuffix(lower, ".gif"):
+		return "image/gif"
+	case strings.HasSuffix(lower, ".webp"):
+		return "image/webp"
+	default:
+		return "image/jpeg"
+	}
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
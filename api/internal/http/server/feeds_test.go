@@ -0,0 +1,140 @@
+package server
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestArticleFeeds(t *testing.T) {
+	source := newFakeArticles()
+	mux := newContentMuxWith(source)
+
+	serve := func(path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("RSS", func(t *testing.T) {
+		rr := serve("/feeds/articles.rss", nil)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rr.Code)
+		}
+		if ct := rr.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/rss+xml") {
+			t.Errorf("unexpected content type %q", ct)
+		}
+		if lm := rr.Header().Get("Last-Modified"); lm != "Thu, 20 Nov 2025 11:00:00 GMT" {
+			t.Errorf("unexpected Last-Modified %q", lm)
+		}
+
+		var doc struct {
+			Channel struct {
+				Items []struct {
+					Link     string   `xml:"link"`
+					Category []string `xml:"category"`
+				} `xml:"item"`
+			} `xml:"channel"`
+		}
+		if err := xml.Unmarshal(rr.Body.Bytes(), &doc); err != nil {
+			t.Fatalf("invalid rss: %v", err)
+		}
+		if len(doc.Channel.Items) != 1 {
+			t.Fatalf("expected 1 published item, got %d", len(doc.Channel.Items))
+		}
+		if got := doc.Channel.Items[0].Link; got != "https://ai-dala.example/articles/getting-started" {
+			t.Errorf("expected absolute slug url, got %q", got)
+		}
+		if !strings.Contains(rr.Body.String(), `<atom:link href="https://ai-dala.example/feeds/articles.rss" rel="self"`) {
+			t.Errorf("expected atom self link in:\n%s", rr.Body.String())
+		}
+	})
+
+	t.Run("Atom", func(t *testing.T) {
+		// The feed ID comes from the public site URL, not from spoofable request headers
+		rr := serve("/feeds/articles.atom", http.Header{"X-Forwarded-Proto": {"gopher"}})
+		if body := rr.Body.String(); strings.Contains(body, "gopher") ||
+			!strings.Contains(body, "<id>https://ai-dala.example/feeds/articles.atom</id>") {
+			t.Errorf("expected the feed id to use the site url in:\n%s", body)
+		}
+		var doc struct {
+			XMLName xml.Name
+			Entries []struct {
+				ID string `xml:"id"`
+			} `xml:"entry"`
+		}
+		if err := xml.Unmarshal(rr.Body.Bytes(), &doc); err != nil {
+			t.Fatalf("invalid atom: %v", err)
+		}
+		if doc.XMLName.Space != "http://www.w3.org/2005/Atom" || len(doc.Entries) != 1 {
+			t.Errorf("unexpected atom document: %+v", doc)
+		}
+	})
+
+	t.Run("JSON Feed", func(t *testing.T) {
+		rr := serve("/feeds/articles.json?category_id=6f1c3a52-9a59-4a43-9a8e-2b1e0f3f4b11&tags=ai,ml&tags=go", nil)
+		var doc jsonFeed
+		if err := json.Unmarshal(rr.Body.Bytes(), &doc); err != nil {
+			t.Fatalf("invalid json feed: %v", err)
+		}
+		if doc.Version != "https://jsonfeed.org/version/1.1" {
+			t.Errorf("unexpected version %q", doc.Version)
+		}
+		if len(doc.Items) != 1 || doc.Items[0].Tags[0] != "tutorial" {
+			t.Errorf("unexpected items %+v", doc.Items)
+		}
+		if source.lastCategoryID != "6f1c3a52-9a59-4a43-9a8e-2b1e0f3f4b11" || strings.Join(source.lastTags, ",") != "ai,ml,go" {
+			t.Errorf("filters not passed through: %q %v", source.lastCategoryID, source.lastTags)
+		}
+	})
+
+	t.Run("Invalid category", func(t *testing.T) {
+		if rr := serve("/feeds/articles.rss?category_id=nope", nil); rr.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("Conditional requests", func(t *testing.T) {
+		first := serve("/feeds/articles.atom", nil)
+		etag := first.Header().Get("ETag")
+		if etag == "" {
+			t.Fatal("expected an ETag")
+		}
+
+		if rr := serve("/feeds/articles.atom", http.Header{"If-None-Match": {etag}}); rr.Code != http.StatusNotModified {
+			t.Errorf("If-None-Match: expected 304, got %d", rr.Code)
+		}
+		if rr := serve("/feeds/articles.atom", http.Header{"If-Modified-Since": {first.Header().Get("Last-Modified")}}); rr.Code != http.StatusNotModified {
+			t.Errorf("If-Modified-Since: expected 304, got %d", rr.Code)
+		}
+		if rr := serve("/feeds/articles.atom", http.Header{"If-None-Match": {`"stale"`}}); rr.Code != http.StatusOK {
+			t.Errorf("stale ETag: expected 200, got %d", rr.Code)
+		}
+	})
+}
+
+func TestNewsFeed(t *testing.T) {
+	mux := newContentMux()
+
+	req := httptest.NewRequest("GET", "/feeds/news.json?category=Tools", nil)
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+
+	var doc jsonFeed
+	if err := json.Unmarshal(rr.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid json feed: %v", err)
+	}
+	if len(doc.Items) != 8 {
+		t.Errorf("expected 8 Tools items, got %d", len(doc.Items))
+	}
+	if !strings.HasPrefix(doc.Items[0].URL, "https://ai-dala.example/news/") {
+		t.Errorf("expected absolute news url, got %q", doc.Items[0].URL)
+	}
+}
@@ -11,6 +11,9 @@ import (
 	"github.com/ai-dala/api/internal/auth"
 	"github.com/ai-dala/api/internal/modules/articles"
 	"github.com/ai-dala/api/internal/modules/categories"
+	"github.com/ai-dala/api/internal/modules/news"
+	"github.com/ai-dala/api/internal/modules/reports"
+	"github.com/ai-dala/api/internal/modules/seo"
 	"github.com/ai-dala/api/internal/modules/tags"
 	"github.com/ai-dala/api/internal/modules/uploads"
 	"github.com/ai-dala/api/internal/modules/user"
@@ -19,21 +22,31 @@ import (
 
 type Server struct {
 	auth              auth.Service
+	verifier          *auth.Verifier
 	tagsHandler       *tags.Handler
 	categoriesHandler *categories.Handler
 	articlesHandler   *articles.Handler
 	uploadsHandler    *uploads.Handler
 	userHandler       *user.Handler
+	newsHandler       *news.Handler
+	seoHandler        *seo.Handler
+	reportsHandler    *reports.Handler
+	content           Content
 }
 
-func NewServer(authService auth.Service, tagsHandler *tags.Handler, categoriesHandler *categories.Handler, articlesHandler *articles.Handler, uploadsHandler *uploads.Handler, userHandler *user.Handler) *Server {
+func NewServer(authService auth.Service, verifier *auth.Verifier, tagsHandler *tags.Handler, categoriesHandler *categories.Handler, articlesHandler *articles.Handler, uploadsHandler *uploads.Handler, userHandler *user.Handler, newsHandler *news.Handler, seoHandler *seo.Handler, reportsHandler *reports.Handler, content Content) *Server {
 	return &Server{
 		auth:              authService,
+		verifier:          verifier,
 		tagsHandler:       tagsHandler,
 		categoriesHandler: categoriesHandler,
 		articlesHandler:   articlesHandler,
 		uploadsHandler:    uploadsHandler,
 		userHandler:       userHandler,
+		newsHandler:       newsHandler,
+		seoHandler:        seoHandler,
+		reportsHandler:    reportsHandler,
+		content:           content,
 	}
 }
 
@@ -74,35 +87,40 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 }
 
 func (s *Server) RegisterRoutes(mux *http.ServeMux) {
-	// Public routes
-	mux.HandleFunc("GET /api/content/news", newsListHandler)
-	mux.HandleFunc("GET /api/content/news/", newsDetailHandler)
-	mux.HandleFunc("GET /api/content/news/{slug}", newsDetailHandler)
-	mux.HandleFunc("GET /api/content/articles", articlesListHandler)
-	mux.HandleFunc("GET /api/content/articles/", articlesDetailHandler)
-	mux.HandleFunc("GET /api/content/articles/{slug}", articlesDetailHandler)
+	// Public content routes
+	if s.content.News != nil {
+		mux.HandleFunc("GET /api/content/news", s.newsListHandler)
+		mux.HandleFunc("GET /api/content/news/", s.newsDetailHandler)
+		mux.HandleFunc("GET /api/content/news/{slug}", s.newsDetailHandler)
+	}
+	if s.content.Articles != nil {
+		mux.HandleFunc("GET /api/content/articles", s.articlesListHandler)
+		mux.HandleFunc("GET /api/content/articles/", s.articlesDetailHandler)
+		mux.HandleFunc("GET /api/content/articles/{slug}", s.articlesDetailHandler)
+	}
+	s.registerFeedRoutes(mux)
 
 	// Test auth endpoint (only in test environment)
 	if os.Getenv("ENV") == "test" {
 		mux.HandleFunc("POST /api/auth/test-token", s.testTokenHandler)
 	}
 
-	// Tag routes (protected - require authentication)
+	// Tag routes (write access requires the taxonomy permission)
 	if s.tagsHandler != nil {
-		mux.HandleFunc("GET /api/tags", s.tagsHandler.ListTags)
+		mux.HandleFunc("GET /api/tags", s.listTags)
 		mux.HandleFunc("GET /api/tags/{code}", s.tagsHandler.GetTagByCode)
-		mux.Handle("POST /api/tags", auth.Middleware(http.HandlerFunc(s.tagsHandler.CreateTag)))
-		mux.Handle("PUT /api/tags/{code}", auth.Middleware(http.HandlerFunc(s.tagsHandler.UpdateTag)))
-		mux.Handle("DELETE /api/tags/{code}", auth.Middleware(http.HandlerFunc(s.tagsHandler.DeleteTag)))
+		mux.Handle("POST /api/tags", s.taxonomyEditor(s.tagsHandler.CreateTag))
+		mux.Handle("PUT /api/tags/{code}", s.taxonomyEditor(s.tagsHandler.UpdateTag))
+		mux.Handle("DELETE /api/tags/{code}", s.taxonomyEditor(s.tagsHandler.DeleteTag))
 	}
 
-	// Categories routes
+	// Categories routes (write access requires the taxonomy permission)
 	if s.categoriesHandler != nil {
 		mux.HandleFunc("GET /api/categories", s.categoriesHandler.ListCategories)
-		mux.HandleFunc("POST /api/categories", s.categoriesHandler.CreateCategory)
+		mux.Handle("POST /api/categories", s.taxonomyEditor(s.categoriesHandler.CreateCategory))
 		mux.HandleFunc("GET /api/categories/{id}", s.categoriesHandler.GetCategory)
-		mux.HandleFunc("PUT /api/categories/{id}", s.categoriesHandler.UpdateCategory)
-		mux.HandleFunc("DELETE /api/categories/{id}", s.categoriesHandler.DeleteCategory)
+		mux.Handle("PUT /api/categories/{id}", s.taxonomyEditor(s.categoriesHandler.UpdateCategory))
+		mux.Handle("DELETE /api/categories/{id}", s.taxonomyEditor(s.categoriesHandler.DeleteCategory))
 	}
 
 	// Articles routes
@@ -120,8 +138,37 @@ func (s *Server) RegisterRoutes(mux *http.ServeMux) {
 		s.userHandler.RegisterRoutes(mux)
 	}
 
+	// News editor routes
+	if s.newsHandler != nil {
+		s.newsHandler.RegisterRoutes(mux)
+	}
+
+	// robots.txt and sitemaps
+	if s.seoHandler != nil {
+		s.seoHandler.RegisterRoutes(mux)
+	}
+
+	// Reader reports and the editor queue
+	if s.reportsHandler != nil {
+		s.reportsHandler.RegisterRoutes(mux)
+	}
+
 	// Protected routes
-	mux.Handle("GET /api/protected/resource", auth.Middleware(http.HandlerFunc(s.protectedHandler)))
+	mux.Handle("GET /api/protected/resource", s.verifier.Middleware(http.HandlerFunc(s.protectedHandler)))
+}
+
+// listTags serves the tag list; popular=true ranks tags by recent activity on their articles
+func (s *Server) listTags(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("popular") == "true" && s.articlesHandler != nil {
+		s.articlesHandler.ListPopularTags(w, r)
+		return
+	}
+	s.tagsHandler.ListTags(w, r)
+}
+
+// taxonomyEditor restricts a handler to authenticated users allowed to manage categories and tags
+func (s *Server) taxonomyEditor(handler http.HandlerFunc) http.Handler {
+	return s.verifier.Middleware(auth.RequirePermission(auth.PermManageTaxonomy)(handler))
 }
 
 func (s *Server) protectedHandler(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,216 @@
+package articles
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrCollectionNotFound  = errors.New("collection not found")
+	ErrInvalidCollection   = errors.New("collection name is required")
+	ErrDuplicateCollection = errors.New("collection already exists")
+)
+
+// maxCollectionNameLength matches bookmark_collections.name
+const maxCollectionNameLength = 100
+
+// Bookmark is an article a reader saved for later, with a preview of its body
+type Bookmark struct {
+	Article
+	CollectionID *string   `db:"collection_id" json:"collection_id"`
+	BookmarkedAt time.Time `db:"bookmarked_at" json:"bookmarked_at"`
+}
+
+// BookmarkCollection is a named reading list of a reader
+type BookmarkCollection struct {
+	ID            string    `db:"id" json:"id"`
+	UserID        string    `db:"user_id" json:"-"`
+	Name          string    `db:"name" json:"name"`
+	BookmarkCount int       `db:"bookmark_count" json:"bookmark_count"`
+	CreatedAt     time.Time `db:"created_at" json:"created_at"`
+}
+
+// AddBookmark saves an article for a user, moving it to the collection if it is already saved
+func (r *Repository) AddBookmark(userID, articleID string, collectionID *string) error {
+	query := `
+		INSERT INTO bookmarks (user_id, article_id, collection_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, article_id)
+		DO UPDATE SET collection_id = EXCLUDED.collection_id
+	`
+	_, err := r.db.Exec(query, userID, articleID, collectionID)
+	return err
+}
+
+// RemoveBookmark removes a saved article
+func (r *Repository) RemoveBookmark(userID, articleID string) error {
+	_, err := r.db.Exec(`DELETE FROM bookmarks WHERE user_id = $1 AND article_id = $2`, userID, articleID)
+	return err
+}
+
+// ListBookmarks retrieves a user's saved published articles, most recently saved first.
+// An empty collectionID lists every bookmark.
+func (r *Repository) ListBookmarks(userID, collectionID string, limit, offset int) ([]Bookmark, int, error) {
+	where := `WHERE b.user_id = $1 AND a.status = 'PUBLISHED' AND a.deleted_at IS NULL`
+	args := []interface{}{userID}
+	if collectionID != "" {
+		where += ` AND b.collection_id = $2`
+		args = append(args, collectionID)
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM bookmarks b JOIN articles a ON a.id = b.article_id ` + where
+	if err := r.db.Get(&total, countQuery, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT a.id, a.title, a.slug, a.body, a.category_id, a.author_id, a.status, a.language, a.published_at,
+			a.created_at, a.updated_at, a.likes_count, a.dislikes_count, a.comments_count,
+			b.collection_id, b.created_at AS bookmarked_at
+		FROM bookmarks b
+		JOIN articles a ON a.id = b.article_id
+		%s
+		ORDER BY b.created_at DESC, a.id
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	bookmarks := []Bookmark{}
+	err := r.db.Select(&bookmarks, query, args...)
+	return bookmarks, total, err
+}
+
+// CreateCollection adds a named collection for a user
+func (r *Repository) CreateCollection(c *BookmarkCollection) error {
+	query := `INSERT INTO bookmark_collections (user_id, name) VALUES ($1, $2) RETURNING id, created_at`
+	return r.db.QueryRow(query, c.UserID, c.Name).Scan(&c.ID, &c.CreatedAt)
+}
+
+// FindCollection retrieves a collection owned by the user, or nil
+func (r *Repository) FindCollection(userID, id string) (*BookmarkCollection, error) {
+	var c BookmarkCollection
+	query := `SELECT id, user_id, name, created_at FROM bookmark_collections WHERE id = $1 AND user_id = $2`
+	err := r.db.Get(&c, query, id, userID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &c, err
+}
+
+// ListCollections retrieves a user's collections with the number of bookmarks in each
+func (r *Repository) ListCollections(userID string) ([]BookmarkCollection, error) {
+	query := `
+		SELECT c.id, c.user_id, c.name, c.created_at,
+			(SELECT COUNT(*) FROM bookmarks b WHERE b.collection_id = c.id) AS bookmark_count
+		FROM bookmark_collections c
+		WHERE c.user_id = $1
+		ORDER BY c.name
+	`
+	collections := []BookmarkCollection{}
+	err := r.db.Select(&collections, query, userID)
+	return collections, err
+}
+
+// DeleteCollection removes a collection; its bookmarks are kept without a collection.
+// It returns sql.ErrNoRows if the user has no such collection.
+func (r *Repository) DeleteCollection(userID, id string) error {
+	result, err := r.db.Exec(`DELETE FROM bookmark_collections WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// AddBookmark saves a published article for the user, optionally in one of the user's collections
+func (s *Service) AddBookmark(userID, articleID string, collectionID *string) error {
+	article, err := s.repo.FindByID(articleID)
+	if err != nil {
+		return err
+	}
+	if article == nil || article.Status != StatusPublished {
+		return ErrArticleNotFound
+	}
+	if collectionID != nil && *collectionID == "" {
+		collectionID = nil
+	}
+	if collectionID != nil {
+		collection, err := s.repo.FindCollection(userID, *collectionID)
+		if err != nil {
+			return err
+		}
+		if collection == nil {
+			return ErrCollectionNotFound
+		}
+	}
+	return s.repo.AddBookmark(userID, articleID, collectionID)
+}
+
+// RemoveBookmark removes a saved article
+func (s *Service) RemoveBookmark(userID, articleID string) error {
+	return s.repo.RemoveBookmark(userID, articleID)
+}
+
+// ListBookmarks retrieves a page of the user's saved articles with text previews
+func (s *Service) ListBookmarks(userID, collectionID string, limit, offset int) ([]Bookmark, int, error) {
+	if collectionID != "" {
+		collection, err := s.repo.FindCollection(userID, collectionID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if collection == nil {
+			return nil, 0, ErrCollectionNotFound
+		}
+	}
+
+	bookmarks, total, err := s.repo.ListBookmarks(userID, collectionID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range bookmarks {
+		bookmarks[i].Body = generatePreview(bookmarks[i].Body)
+	}
+	return bookmarks, total, nil
+}
+
+// CreateCollection adds a named collection for the user
+func (s *Service) CreateCollection(userID, name string) (*BookmarkCollection, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > maxCollectionNameLength {
+		return nil, ErrInvalidCollection
+	}
+	collection := &BookmarkCollection{UserID: userID, Name: name}
+	err := s.repo.CreateCollection(collection)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return nil, ErrDuplicateCollection
+	}
+	return collection, err
+}
+
+// ListCollections retrieves the user's collections
+func (s *Service) ListCollections(userID string) ([]BookmarkCollection, error) {
+	return s.repo.ListCollections(userID)
+}
+
+// DeleteCollection removes one of the user's collections, keeping its bookmarks
+func (s *Service) DeleteCollection(userID, id string) error {
+	if err := s.repo.DeleteCollection(userID, id); errors.Is(err, sql.ErrNoRows) {
+		return ErrCollectionNotFound
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
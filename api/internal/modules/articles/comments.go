@@ -0,0 +1,93 @@
+package articles
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+// Comment page sizes
+const (
+	defaultCommentPageSize = 20
+	maxCommentPageSize     = 100
+)
+
+// DeletedCommentBody replaces the body of a deleted comment that is kept for its replies
+const DeletedCommentBody = "[deleted]"
+
+// CommentCursor marks the position after the last comment of a page
+type CommentCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// Encode returns the opaque string form of the cursor handed to clients
+func (c CommentCursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCommentCursor parses a cursor produced by Encode; an empty string means the first page
+func DecodeCommentCursor(s string) (*CommentCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, ErrInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &CommentCursor{CreatedAt: t, ID: id}, nil
+}
+
+// CommentPage is one page of top-level comments or replies
+type CommentPage struct {
+	Comments   []Comment `json:"comments"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+	HasMore    bool      `json:"has_more"`
+}
+
+// newCommentPage trims the extra row fetched to detect further pages and sets the cursor
+func newCommentPage(comments []Comment, limit int) *CommentPage {
+	page := &CommentPage{Comments: comments}
+	if page.Comments == nil {
+		page.Comments = []Comment{}
+	}
+	for i := range page.Comments {
+		page.Comments[i].redact()
+	}
+	if len(page.Comments) > limit {
+		page.Comments = page.Comments[:limit]
+		last := page.Comments[limit-1]
+		page.HasMore = true
+		page.NextCursor = CommentCursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	return page
+}
+
+// clampCommentLimit applies the default and maximum page size
+func clampCommentLimit(limit int) int {
+	if limit <= 0 {
+		return defaultCommentPageSize
+	}
+	if limit > maxCommentPageSize {
+		return maxCommentPageSize
+	}
+	return limit
+}
+
+// redact hides moderation details, and the body and author of a deleted comment
+func (c *Comment) redact() {
+	c.ModerationReason, c.ModeratedBy, c.ModeratedAt = nil, nil, nil
+	if c.DeletedAt != nil {
+		c.Body = DeletedCommentBody
+		c.UserID = ""
+	}
+}
@@ -0,0 +1,78 @@
+package articles
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentCursor_RoundTrip(t *testing.T) {
+	cursor := CommentCursor{
+		CreatedAt: time.Date(2025, 11, 20, 10, 0, 0, 123456000, time.UTC),
+		ID:        "00000000-0000-0000-0000-000000000042",
+	}
+
+	decoded, err := DecodeCommentCursor(cursor.Encode())
+	require.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, cursor.ID, decoded.ID)
+}
+
+func TestDecodeCommentCursor(t *testing.T) {
+	decoded, err := DecodeCommentCursor("")
+	require.NoError(t, err)
+	assert.Nil(t, decoded)
+
+	for _, raw := range []string{"not base64!", "bm8tc2VwYXJhdG9y", "bm90LWEtdGltZXxpZA"} {
+		_, err := DecodeCommentCursor(raw)
+		assert.ErrorIs(t, err, ErrInvalidCursor, raw)
+	}
+}
+
+func TestNewCommentPage(t *testing.T) {
+	base := time.Date(2025, 11, 20, 10, 0, 0, 0, time.UTC)
+	var comments []Comment
+	for i := 0; i < 3; i++ {
+		comments = append(comments, Comment{ID: fmt.Sprintf("c%d", i), CreatedAt: base.Add(time.Duration(-i) * time.Minute)})
+	}
+
+	t.Run("Extra row means more pages", func(t *testing.T) {
+		page := newCommentPage(comments, 2)
+		assert.Len(t, page.Comments, 2)
+		assert.True(t, page.HasMore)
+
+		cursor, err := DecodeCommentCursor(page.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, "c1", cursor.ID)
+	})
+
+	t.Run("Last page", func(t *testing.T) {
+		page := newCommentPage(comments, 3)
+		assert.Len(t, page.Comments, 3)
+		assert.False(t, page.HasMore)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("Deleted placeholder", func(t *testing.T) {
+		deletedAt := base
+		page := newCommentPage([]Comment{{ID: "c0", UserID: "user-1", Body: "secret", DeletedAt: &deletedAt, ReplyCount: 2}}, 20)
+		assert.Equal(t, DeletedCommentBody, page.Comments[0].Body)
+		assert.Empty(t, page.Comments[0].UserID)
+		assert.Equal(t, 2, page.Comments[0].ReplyCount)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		page := newCommentPage(nil, 20)
+		assert.NotNil(t, page.Comments)
+		assert.False(t, page.HasMore)
+	})
+}
+
+func TestClampCommentLimit(t *testing.T) {
+	assert.Equal(t, defaultCommentPageSize, clampCommentLimit(0))
+	assert.Equal(t, 5, clampCommentLimit(5))
+	assert.Equal(t, maxCommentPageSize, clampCommentLimit(1000))
+}
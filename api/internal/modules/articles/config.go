@@ -0,0 +1,173 @@
+package articles
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config tunes the behaviour of the articles service
+type Config struct {
+	// MaxCommentDepth is the deepest reply level allowed; top-level comments have depth 0
+	MaxCommentDepth int
+	Moderation      ModerationConfig
+	Related         RelatedConfig
+	Embeddings      EmbeddingConfig
+	// TrustedProxies is the number of reverse proxies in front of the API that append the
+	// address they saw to X-Forwarded-For. With zero the header is ignored, since clients
+	// can set it freely.
+	TrustedProxies int
+}
+
+// EmbeddingConfig selects the embedding provider of semantic search and how articles are split
+type EmbeddingConfig struct {
+	// Provider is "http" for production, "local" (feature hashing, no external service) for
+	// tests and development, or "none", the default, to disable semantic search
+	Provider string
+	// URL, APIKey and Model address an OpenAI-compatible embeddings endpoint for the http provider
+	URL    string
+	APIKey string
+	Model  string
+	// Dimensions is the vector size of the local provider
+	Dimensions int
+	// ChunkWords and ChunkOverlap size the overlapping chunks of an article body that are embedded
+	ChunkWords   int
+	ChunkOverlap int
+	// MinSimilarity is the cosine similarity a chunk needs for its article to match a query
+	MinSimilarity float64
+}
+
+// RelatedConfig tunes the related reading recommendations
+type RelatedConfig struct {
+	// Limit is the number of related articles returned when a request does not ask for a number
+	Limit int
+	// CacheTTL is how long recommendations for an article are reused; zero disables caching
+	CacheTTL time.Duration
+}
+
+// ModerationConfig drives the filters that decide the initial status of a comment.
+// A zero value disables the corresponding filter.
+type ModerationConfig struct {
+	// MaxLinks is the number of links a comment may contain before it is held for review
+	MaxLinks int
+	// BlockedWords lists words, per language code (en, ru, kk), that hold a comment for review.
+	// A trailing "*" matches any word with that prefix.
+	BlockedWords map[string][]string
+	// RepeatWindow is how far back identical comments from the same user are treated as spam
+	RepeatWindow time.Duration
+	// MinApprovedComments is how many approved comments a user needs before new ones skip review
+	MinApprovedComments int
+}
+
+// DefaultConfig returns the configuration used when nothing is overridden
+func DefaultConfig() Config {
+	return Config{
+		MaxCommentDepth: 5,
+		Moderation: ModerationConfig{
+			MaxLinks: 2,
+			BlockedWords: map[string][]string{
+				"en": {"casino*", "viagra", "porn*", "betting"},
+				"ru": {"казино", "виагр*", "порн*", "ставк*"},
+				"kk": {"казино", "порн*", "бәс*", "құмар*"},
+			},
+			RepeatWindow:        24 * time.Hour,
+			MinApprovedComments: 1,
+		},
+		Related: RelatedConfig{
+			Limit:    5,
+			CacheTTL: 10 * time.Minute,
+		},
+		Embeddings: EmbeddingConfig{
+			Provider:      EmbeddingProviderNone,
+			Model:         "text-embedding-3-small",
+			Dimensions:    256,
+			ChunkWords:    200,
+			ChunkOverlap:  40,
+			MinSimilarity: 0.25,
+		},
+	}
+}
+
+// ConfigFromEnv reads the service configuration from environment variables
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig()
+	if v := os.Getenv("COMMENTS_MAX_DEPTH"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.MaxCommentDepth = n
+		}
+	}
+	if v := os.Getenv("COMMENTS_MAX_LINKS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.Moderation.MaxLinks = n
+		}
+	}
+	if v := os.Getenv("COMMENTS_MIN_APPROVED"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.Moderation.MinApprovedComments = n
+		}
+	}
+	if v := os.Getenv("COMMENTS_REPEAT_WINDOW"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfg.Moderation.RepeatWindow = d
+		}
+	}
+	if v := os.Getenv("RELATED_ARTICLES_LIMIT"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.Related.Limit = n
+		}
+	}
+	if v := os.Getenv("RELATED_CACHE_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfg.Related.CacheTTL = d
+		}
+	}
+	if v := os.Getenv("EMBEDDINGS_PROVIDER"); v != "" {
+		cfg.Embeddings.Provider = v
+	}
+	if v := os.Getenv("EMBEDDINGS_URL"); v != "" {
+		cfg.Embeddings.URL = v
+	}
+	if v := os.Getenv("EMBEDDINGS_API_KEY"); v != "" {
+		cfg.Embeddings.APIKey = v
+	}
+	if v := os.Getenv("EMBEDDINGS_MODEL"); v != "" {
+		cfg.Embeddings.Model = v
+	}
+	if v := os.Getenv("EMBEDDINGS_DIMENSIONS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.Embeddings.Dimensions = n
+		}
+	}
+	if v := os.Getenv("EMBEDDINGS_CHUNK_WORDS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.Embeddings.ChunkWords = n
+		}
+	}
+	if v := os.Getenv("EMBEDDINGS_MIN_SIMILARITY"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil && f >= -1 && f <= 1 {
+			cfg.Embeddings.MinSimilarity = f
+		}
+	}
+	if v := os.Getenv("TRUSTED_PROXIES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.TrustedProxies = n
+		}
+	}
+	for _, lang := range []string{"en", "ru", "kk"} {
+		if v, ok := os.LookupEnv("COMMENTS_BLOCKED_WORDS_" + strings.ToUpper(lang)); ok {
+			cfg.Moderation.BlockedWords[lang] = splitWords(v)
+		}
+	}
+	return cfg
+}
+
+func splitWords(value string) []string {
+	var words []string
+	for _, w := range strings.Split(value, ",") {
+		if w = strings.TrimSpace(w); w != "" {
+			words = append(words, w)
+		}
+	}
+	return words
+}
@@ -0,0 +1,113 @@
+package articles
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrDiffTooLarge is returned when the changed part of two texts is too large to diff
+var ErrDiffTooLarge = errors.New("revisions differ in too many lines to diff")
+
+// maxDiffCells bounds the LCS table of the changed lines (4M cells, 16 MB)
+const maxDiffCells = 4 << 20
+
+// DiffOp is the kind of change for a line in a diff
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffInsert DiffOp = "insert"
+	DiffDelete DiffOp = "delete"
+)
+
+// DiffLine is a single line of a line-level diff.
+// OldLine and NewLine are 1-based line numbers, zero when the line is absent on that side.
+type DiffLine struct {
+	Op      DiffOp `json:"op"`
+	Text    string `json:"text"`
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+}
+
+// DiffLines computes a line-level diff between two texts using the longest common subsequence.
+// Unchanged leading and trailing lines are skipped, and ErrDiffTooLarge is returned when
+// the lines in between would need more than maxDiffCells to compare.
+func DiffLines(oldText, newText string) ([]DiffLine, error) {
+	a := splitLines(oldText)
+	b := splitLines(newText)
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+	if (len(midA)+1)*(len(midB)+1) > maxDiffCells {
+		return nil, ErrDiffTooLarge
+	}
+
+	diff := make([]DiffLine, 0, len(a)+len(b))
+	for i := 0; i < prefix; i++ {
+		diff = append(diff, DiffLine{Op: DiffEqual, Text: a[i], OldLine: i + 1, NewLine: i + 1})
+	}
+	diff = appendLCSDiff(diff, midA, midB, prefix)
+	for k := suffix; k > 0; k-- {
+		i, j := len(a)-k, len(b)-k
+		diff = append(diff, DiffLine{Op: DiffEqual, Text: a[i], OldLine: i + 1, NewLine: j + 1})
+	}
+	return diff, nil
+}
+
+// appendLCSDiff diffs a and b, whose first lines are line offset+1 of their texts
+func appendLCSDiff(diff []DiffLine, a, b []string, offset int) []DiffLine {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, DiffLine{Op: DiffEqual, Text: a[i], OldLine: offset + i + 1, NewLine: offset + j + 1})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, DiffLine{Op: DiffDelete, Text: a[i], OldLine: offset + i + 1})
+			i++
+		default:
+			diff = append(diff, DiffLine{Op: DiffInsert, Text: b[j], NewLine: offset + j + 1})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, DiffLine{Op: DiffDelete, Text: a[i], OldLine: offset + i + 1})
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, DiffLine{Op: DiffInsert, Text: b[j], NewLine: offset + j + 1})
+	}
+	return diff
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
@@ -0,0 +1,66 @@
+package articles
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffLines(t *testing.T) {
+	t.Run("Identical", func(t *testing.T) {
+		diff, err := DiffLines("a\nb", "a\nb")
+		require.NoError(t, err)
+		assert.Equal(t, []DiffLine{
+			{Op: DiffEqual, Text: "a", OldLine: 1, NewLine: 1},
+			{Op: DiffEqual, Text: "b", OldLine: 2, NewLine: 2},
+		}, diff)
+	})
+
+	t.Run("Insert, delete and change", func(t *testing.T) {
+		oldBody := "# Title\nintro\nold paragraph\nfooter"
+		newBody := "# Title\nintro\nnew paragraph\nextra\nfooter"
+
+		diff, err := DiffLines(oldBody, newBody)
+		require.NoError(t, err)
+		assert.Equal(t, []DiffLine{
+			{Op: DiffEqual, Text: "# Title", OldLine: 1, NewLine: 1},
+			{Op: DiffEqual, Text: "intro", OldLine: 2, NewLine: 2},
+			{Op: DiffDelete, Text: "old paragraph", OldLine: 3},
+			{Op: DiffInsert, Text: "new paragraph", NewLine: 3},
+			{Op: DiffInsert, Text: "extra", NewLine: 4},
+			{Op: DiffEqual, Text: "footer", OldLine: 4, NewLine: 5},
+		}, diff)
+	})
+
+	t.Run("From empty", func(t *testing.T) {
+		diff, err := DiffLines("", "line\r\nnext")
+		require.NoError(t, err)
+		assert.Equal(t, []DiffLine{
+			{Op: DiffInsert, Text: "line", NewLine: 1},
+			{Op: DiffInsert, Text: "next", NewLine: 2},
+		}, diff)
+	})
+
+	t.Run("Too large", func(t *testing.T) {
+		oldLines := make([]string, 3000)
+		newLines := make([]string, 3000)
+		for i := range oldLines {
+			oldLines[i] = fmt.Sprintf("old %d", i)
+			newLines[i] = fmt.Sprintf("new %d", i)
+		}
+		_, err := DiffLines(strings.Join(oldLines, "\n"), strings.Join(newLines, "\n"))
+		assert.ErrorIs(t, err, ErrDiffTooLarge)
+
+		// Long texts with a small change only compare the changed lines
+		edited := append([]string{}, oldLines...)
+		edited[1500] = "changed"
+		diff, err := DiffLines(strings.Join(oldLines, "\n"), strings.Join(edited, "\n"))
+		require.NoError(t, err)
+		assert.Len(t, diff, 3001)
+		assert.Equal(t, DiffLine{Op: DiffInsert, Text: "changed", NewLine: 1501}, diff[1501])
+		assert.Equal(t, DiffLine{Op: DiffEqual, Text: "old 2999", OldLine: 3000, NewLine: 3000}, diff[3000])
+	})
+}
@@ -0,0 +1,446 @@
+package articles
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Embedding providers of EmbeddingConfig
+const (
+	EmbeddingProviderLocal = "local"
+	EmbeddingProviderHTTP  = "http"
+	EmbeddingProviderNone  = "none"
+)
+
+// maxEmbeddingResponseSize bounds how much of an embeddings response is read
+const maxEmbeddingResponseSize = 32 << 20
+
+// embedTimeout bounds embedding an article after it is saved
+const embedTimeout = 30 * time.Second
+
+// Embedder turns texts into unit-length vectors whose cosine similarity reflects how close
+// their meanings are. Model names the vector space; vectors of different models are not comparable.
+type Embedder interface {
+	Model() string
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// NewEmbedder builds the embedder selected by the configuration, or nil when semantic search
+// is disabled. An unknown provider disables it too rather than falling back to hashing.
+func NewEmbedder(cfg EmbeddingConfig) Embedder {
+	switch cfg.Provider {
+	case EmbeddingProviderNone, "":
+		return nil
+	case EmbeddingProviderHTTP:
+		return NewHTTPEmbedder(cfg.URL, cfg.APIKey, cfg.Model)
+	case EmbeddingProviderLocal:
+		return LocalEmbedder{Dimensions: cfg.Dimensions}
+	default:
+		log.Printf("[EMBEDDINGS] Unknown provider %q, semantic search is disabled", cfg.Provider)
+		return nil
+	}
+}
+
+// LocalEmbedder hashes the words of a text into a fixed number of dimensions. It needs no
+// external service and is deterministic, which suits tests and development, but it only
+// captures shared words, not meaning.
+type LocalEmbedder struct {
+	Dimensions int
+}
+
+func (e LocalEmbedder) dimensions() int {
+	if e.Dimensions <= 0 {
+		return 256
+	}
+	return e.Dimensions
+}
+
+func (e LocalEmbedder) Model() string {
+	return fmt.Sprintf("local-hash-%d", e.dimensions())
+}
+
+func (e LocalEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	dims := e.dimensions()
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vector := make([]float32, dims)
+		for _, word := range searchWords(text) {
+			h := fnv.New32a()
+			h.Write([]byte(word))
+			sum := h.Sum32()
+			// The top bit picks the sign so colliding words tend to cancel out rather than add up
+			if sum&(1<<31) != 0 {
+				vector[sum%uint32(dims)]--
+			} else {
+				vector[sum%uint32(dims)]++
+			}
+		}
+		vectors[i] = normalize(vector)
+	}
+	return vectors, nil
+}
+
+// HTTPEmbedder calls an OpenAI-compatible embeddings endpoint
+type HTTPEmbedder struct {
+	url    string
+	apiKey string
+	model  string
+	client *http.Client
+}
+
+func NewHTTPEmbedder(url, apiKey, model string) *HTTPEmbedder {
+	return &HTTPEmbedder{
+		url:    url,
+		apiKey: apiKey,
+		model:  model,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (e *HTTPEmbedder) Model() string {
+	return e.model
+}
+
+func (e *HTTPEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if e.url == "" {
+		return nil, errors.New("embeddings URL is not configured")
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"model": e.model,
+		"input": texts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxEmbeddingResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("embeddings request failed with status %d: %s", resp.StatusCode, truncate(string(body), 200))
+	}
+
+	var result struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("decode embeddings: %w", err)
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, d := range result.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embedding index %d out of range", d.Index)
+		}
+		vectors[d.Index] = normalize(d.Embedding)
+	}
+	for i, v := range vectors {
+		if len(v) == 0 {
+			return nil, fmt.Errorf("missing embedding for input %d", i)
+		}
+	}
+	return vectors, nil
+}
+
+// normalize scales a vector to unit length in place
+func normalize(vector []float32) []float32 {
+	var sum float64
+	for _, x := range vector {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return vector
+	}
+	norm := float32(math.Sqrt(sum))
+	for i := range vector {
+		vector[i] /= norm
+	}
+	return vector
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
+
+// chunkText splits text into chunks of size words, each repeating the last overlap words
+// of the previous one so a passage cut in two is still embedded whole once
+func chunkText(text string, size, overlap int) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+	if size <= 0 {
+		size = 200
+	}
+	step := size - overlap
+	if overlap < 0 || step < 1 {
+		step = size
+	}
+
+	var chunks []string
+	for start := 0; ; start += step {
+		end := min(start+size, len(words))
+		chunks = append(chunks, strings.Join(words[start:end], " "))
+		if end == len(words) {
+			break
+		}
+	}
+	return chunks
+}
+
+// articleChunks splits the plain text of an article into the passages that are embedded.
+// Each passage starts with the title so it keeps its context.
+func articleChunks(article *Article, cfg EmbeddingConfig) []string {
+	chunks := chunkText(plainText(article.Body), cfg.ChunkWords, cfg.ChunkOverlap)
+	if len(chunks) == 0 {
+		if strings.TrimSpace(article.Title) == "" {
+			return nil
+		}
+		return []string{article.Title}
+	}
+	for i := range chunks {
+		chunks[i] = article.Title + "\n\n" + chunks[i]
+	}
+	return chunks
+}
+
+// EmbeddedChunks retrieves the text of the chunks of an article embedded with a model, in order
+func (r *Repository) EmbeddedChunks(articleID, model string) ([]string, error) {
+	chunks := []string{}
+	err := r.db.Select(&chunks, `
+		SELECT content FROM article_embeddings
+		WHERE article_id = $1 AND model = $2
+		ORDER BY chunk
+	`, articleID, model)
+	return chunks, err
+}
+
+// ReplaceEmbeddings stores the chunk embeddings of the version of an article last saved at
+// version, dropping those of earlier versions and of other models. The run is recorded even
+// when there are no chunks. Nothing is written if the article was saved again since, so an
+// embedding finishing late never overwrites that of a newer save.
+func (r *Repository) ReplaceEmbeddings(ctx context.Context, articleID, model string, version time.Time, chunks []string, vectors [][]float32) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The share lock keeps the article from being saved until the embeddings are stored
+	var updatedAt time.Time
+	err = tx.GetContext(ctx, &updatedAt, `SELECT updated_at FROM articles WHERE id = $1 FOR SHARE`, articleID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !updatedAt.Equal(version)) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM article_embeddings WHERE article_id = $1`, articleID); err != nil {
+		return err
+	}
+	for i, content := range chunks {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO article_embeddings (article_id, chunk, model, content, embedding)
+			VALUES ($1, $2, $3, $4, $5)
+		`, articleID, i, model, content, pq.Array(vectors[i]))
+		if err != nil {
+			return err
+		}
+	}
+	if err := recordEmbeddingRun(ctx, tx, articleID, model, version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// TouchEmbeddings marks the embeddings of an article as up to date with the version last
+// saved at version, unless the article was saved again since
+func (r *Repository) TouchEmbeddings(ctx context.Context, articleID, model string, version time.Time) error {
+	return recordEmbeddingRun(ctx, r.db, articleID, model, version)
+}
+
+// recordEmbeddingRun stamps the run with the save it embedded, so ListUnembedded finds the
+// article again once it changes. A version that is no longer current is not recorded.
+func recordEmbeddingRun(ctx context.Context, db sqlx.ExecerContext, articleID, model string, version time.Time) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO article_embedding_runs (article_id, model, embedded_at)
+		SELECT id, $2, updated_at FROM articles WHERE id = $1 AND updated_at = $3
+		ON CONFLICT (article_id) DO UPDATE SET model = EXCLUDED.model, embedded_at = EXCLUDED.embedded_at
+	`, articleID, model, version)
+	return err
+}
+
+// ListUnembedded retrieves published articles without an embedding run for a model or changed since embedded
+func (r *Repository) ListUnembedded(model string, limit int) ([]string, error) {
+	query := `
+		SELECT a.id
+		FROM articles a
+		LEFT JOIN article_embedding_runs e ON e.article_id = a.id AND e.model = $1
+		WHERE a.status = 'PUBLISHED' AND a.deleted_at IS NULL
+		  AND (e.embedded_at IS NULL OR e.embedded_at < a.updated_at)
+		ORDER BY a.published_at DESC
+		LIMIT $2
+	`
+	ids := []string{}
+	err := r.db.Select(&ids, query, model, limit)
+	return ids, err
+}
+
+// IndexEmbeddings embeds the chunks of an article with the configured provider. Chunks that
+// have not changed since they were embedded with the same model are kept.
+func (s *Service) IndexEmbeddings(ctx context.Context, articleID string) error {
+	if s.embedder == nil {
+		return nil
+	}
+	article, err := s.repo.FindByID(articleID)
+	if err != nil {
+		return err
+	}
+	if article == nil {
+		return ErrArticleNotFound
+	}
+
+	model := s.embedder.Model()
+	chunks := articleChunks(article, s.config.Embeddings)
+	existing, err := s.repo.EmbeddedChunks(articleID, model)
+	if err != nil {
+		return err
+	}
+	if len(chunks) > 0 && slices.Equal(existing, chunks) {
+		return s.repo.TouchEmbeddings(ctx, articleID, model, article.UpdatedAt)
+	}
+
+	var vectors [][]float32
+	if len(chunks) > 0 {
+		vectors, err = s.embedder.Embed(ctx, chunks)
+		if err != nil {
+			return err
+		}
+	}
+	return s.repo.ReplaceEmbeddings(ctx, articleID, model, article.UpdatedAt, chunks, vectors)
+}
+
+// embedArticle refreshes the embeddings of a saved article in the background, so a slow
+// embedding provider never delays the save. A failure is only logged; the EmbeddingIndexer
+// retries published articles later.
+func (s *Service) embedArticle(articleID string) {
+	if s.embedder == nil {
+		return
+	}
+	s.embedding.Add(1)
+	go func() {
+		defer s.embedding.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), embedTimeout)
+		defer cancel()
+		if err := s.IndexEmbeddings(ctx, articleID); err != nil {
+			log.Printf("[EMBED] Indexing article %s failed: %v", articleID, err)
+		}
+	}()
+}
+
+// embeddingBatchSize is the number of articles the indexer embeds per run
+const embeddingBatchSize = 100
+
+// EmbeddingIndexer periodically embeds published articles whose embeddings are missing,
+// stale or from another model: articles published by the scheduler, saves whose embedding
+// failed, and everything after the embedding model changes.
+type EmbeddingIndexer struct {
+	service  *Service
+	interval time.Duration
+}
+
+func NewEmbeddingIndexer(service *Service, interval time.Duration) *EmbeddingIndexer {
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+	return &EmbeddingIndexer{service: service, interval: interval}
+}
+
+// Run indexes articles every interval until the context is cancelled
+func (i *EmbeddingIndexer) Run(ctx context.Context) {
+	if i.service.embedder == nil {
+		log.Println("[EMBED] Disabled")
+		return
+	}
+	ticker := time.NewTicker(i.interval)
+	defer ticker.Stop()
+
+	log.Printf("[EMBED] Started with model %s, interval %s", i.service.embedder.Model(), i.interval)
+	for {
+		indexed, err := i.RunOnce(ctx)
+		if err != nil {
+			log.Printf("[EMBED] Run failed: %v", err)
+		}
+		if indexed > 0 {
+			log.Printf("[EMBED] Indexed %d articles", indexed)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("[EMBED] Stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce indexes one batch of articles and returns how many were indexed. A failing article
+// does not stop the others; their errors are joined in the returned error.
+func (i *EmbeddingIndexer) RunOnce(ctx context.Context) (int, error) {
+	if i.service.embedder == nil {
+		return 0, nil
+	}
+	ids, err := i.service.repo.ListUnembedded(i.service.embedder.Model(), embeddingBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	indexed := 0
+	var errs []error
+	for _, id := range ids {
+		if err := i.service.IndexEmbeddings(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", id, err))
+			continue
+		}
+		indexed++
+	}
+	return indexed, errors.Join(errs...)
+}
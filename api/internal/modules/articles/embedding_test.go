@@ -0,0 +1,122 @@
+package articles
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
+
+func TestLocalEmbedder(t *testing.T) {
+	e := LocalEmbedder{Dimensions: 64}
+	assert.Equal(t, "local-hash-64", e.Model())
+
+	vectors, err := e.Embed(context.Background(), []string{"Quantum computing", "quantum computing!", "Tomato gardens", ""})
+	require.NoError(t, err)
+	require.Len(t, vectors, 4)
+	assert.Len(t, vectors[0], 64)
+	assert.InDelta(t, 1, dot(vectors[0], vectors[0]), 1e-6)
+	assert.InDelta(t, 1, dot(vectors[0], vectors[1]), 1e-6)
+	assert.Less(t, dot(vectors[0], vectors[2]), 0.9)
+	assert.Zero(t, dot(vectors[3], vectors[3]))
+}
+
+func TestHTTPEmbedder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		var req struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "test-model", req.Model)
+		assert.Equal(t, []string{"a", "b"}, req.Input)
+		// Entries may come back in any order
+		w.Write([]byte(`{"data":[{"index":1,"embedding":[0,2]},{"index":0,"embedding":[3,4]}]}`))
+	}))
+	defer server.Close()
+
+	e := NewHTTPEmbedder(server.URL, "secret", "test-model")
+	assert.Equal(t, "test-model", e.Model())
+	vectors, err := e.Embed(context.Background(), []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{0.6, 0.8}, {0, 1}}, vectors)
+}
+
+func TestHTTPEmbedder_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/short" {
+			w.Write([]byte(`{"data":[{"index":0,"embedding":[1]}]}`))
+			return
+		}
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPEmbedder(server.URL, "", "m").Embed(context.Background(), []string{"a"})
+	assert.ErrorContains(t, err, "status 429")
+
+	_, err = NewHTTPEmbedder(server.URL+"/short", "", "m").Embed(context.Background(), []string{"a", "b"})
+	assert.ErrorContains(t, err, "missing embedding for input 1")
+}
+
+func TestNewEmbedder(t *testing.T) {
+	assert.Nil(t, NewEmbedder(EmbeddingConfig{Provider: EmbeddingProviderNone}))
+	assert.Nil(t, NewEmbedder(DefaultConfig().Embeddings))
+	assert.Nil(t, NewEmbedder(EmbeddingConfig{Provider: "fancy"}))
+	assert.IsType(t, &HTTPEmbedder{}, NewEmbedder(EmbeddingConfig{Provider: EmbeddingProviderHTTP, URL: "http://embed"}))
+	assert.Equal(t, LocalEmbedder{Dimensions: 32}, NewEmbedder(EmbeddingConfig{Provider: EmbeddingProviderLocal, Dimensions: 32}))
+}
+
+func TestChunkText(t *testing.T) {
+	assert.Nil(t, chunkText("  ", 3, 1))
+	assert.Equal(t, []string{"a b c", "c d e", "e f"}, chunkText("a b\nc d e f", 3, 1))
+	assert.Equal(t, []string{"a b", "c"}, chunkText("a b c", 2, 5))
+
+	article := &Article{Title: "Title", Body: "## Heading\n**bold** text"}
+	assert.Equal(t, []string{"Title\n\nHeading bold text"}, articleChunks(article, EmbeddingConfig{ChunkWords: 10}))
+	assert.Equal(t, []string{"Title"}, articleChunks(&Article{Title: "Title"}, EmbeddingConfig{}))
+}
+
+func TestService_Search_Modes(t *testing.T) {
+	service := &Service{}
+	_, err := service.Search(context.Background(), SearchOptions{Query: "agents", Mode: "fuzzy"})
+	assert.ErrorIs(t, err, ErrInvalidSearchMode)
+
+	_, err = service.Search(context.Background(), SearchOptions{Query: "agents", Mode: SearchModeSemantic})
+	assert.ErrorIs(t, err, ErrSemanticSearchUnavailable)
+}
+
+func TestSearchWhere_Semantic(t *testing.T) {
+	opts := SearchOptions{Query: "agents -crypto title:tools", Mode: SearchModeSemantic, Embedding: []float32{1, 0}, EmbeddingModel: "m", MinSimilarity: 0.3}
+
+	where, args := searchWhere(opts, filterNone)
+	assert.Contains(t, where, "cosine_similarity(e.embedding, $1::real[])")
+	assert.Contains(t, where, ">= $3")
+	assert.Equal(t, []interface{}{args[0], "m", 0.3, "-crypto", "tools"}, args)
+	assert.Contains(t, searchScore(opts, 6), "MAX(cosine_similarity")
+
+	// Exclusions also apply to articles matched by meaning only
+	opts.Mode = SearchModeHybrid
+	where, args = searchWhere(opts, filterNone)
+	assert.Contains(t, where, ">= $3 OR ((a.language = 'en' AND a.search_vector @@ websearch_to_tsquery('english', $4))")
+	assert.Contains(t, where, ") AND ((a.language = 'en' AND a.search_vector @@ websearch_to_tsquery('english', $5))")
+	assert.Equal(t, []interface{}{args[0], "m", 0.3, "agents -crypto", "-crypto", "tools"}, args)
+	assert.Contains(t, searchScore(opts, 7), "0.4 * ts_rank(a.search_vector, websearch_to_tsquery(article_search_config(a.language), $7)")
+
+	// Without a query vector the search falls back to keywords
+	opts.Embedding = nil
+	assert.NotContains(t, searchScore(opts, 1), "cosine_similarity")
+}
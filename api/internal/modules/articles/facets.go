@@ -0,0 +1,105 @@
+package articles
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxFacetBuckets bounds the number of categories and tags counted per search
+const maxFacetBuckets = 20
+
+// searchMonthLayout is the format of published month buckets
+const searchMonthLayout = "2006-01"
+
+// FacetBucket is the number of search results sharing a value. Labels of tags and
+// categories are in the search language, English when searching every language.
+type FacetBucket struct {
+	Value string `db:"value" json:"value"`
+	Label string `db:"label" json:"label,omitempty"`
+	Count int    `db:"count" json:"count"`
+}
+
+// SearchFacets are the refinements available for a search. Each group is counted without
+// its own filter so clients can offer the alternatives to what is selected, except tags
+// that must all match, which narrow down further.
+type SearchFacets struct {
+	Categories []FacetBucket `json:"categories"`
+	Tags       []FacetBucket `json:"tags"`
+	Months     []FacetBucket `json:"published_months"`
+}
+
+// searchFacets counts the results of a search per category, tag and published month
+func (r *Repository) searchFacets(opts SearchOptions) (*SearchFacets, error) {
+	lang := opts.Language
+	if lang == "" {
+		lang = "en"
+	}
+	facets := &SearchFacets{}
+
+	where, args := searchWhere(opts, filterCategories)
+	query := fmt.Sprintf(`
+		SELECT c.id AS value, COALESCE(NULLIF(c.name->>$%d, ''), c.code) AS label, COUNT(*) AS count
+		FROM articles a
+		JOIN categories c ON c.id = a.category_id AND c.deleted_at IS NULL`+where+`
+		GROUP BY c.id, c.code, c.name
+		ORDER BY count DESC, label
+		LIMIT %d
+	`, len(args)+1, maxFacetBuckets)
+	facets.Categories = []FacetBucket{}
+	if err := r.db.Select(&facets.Categories, query, append(args, lang)...); err != nil {
+		return nil, err
+	}
+
+	skip := filterTags
+	if opts.MatchAllTags {
+		skip = filterNone
+	}
+	where, args = searchWhere(opts, skip)
+	query = fmt.Sprintf(`
+		SELECT t.code AS value, COALESCE(NULLIF(t.name->>$%d, ''), t.code) AS label, COUNT(*) AS count
+		FROM articles a
+		JOIN article_tags at ON at.article_id = a.id
+		JOIN tags t ON t.id = at.tag_id`+where+`
+		GROUP BY t.code, t.name
+		ORDER BY count DESC, value
+		LIMIT %d
+	`, len(args)+1, maxFacetBuckets)
+	facets.Tags = []FacetBucket{}
+	if err := r.db.Select(&facets.Tags, query, append(args, lang)...); err != nil {
+		return nil, err
+	}
+
+	where, args = searchWhere(opts, filterPublished)
+	query = `
+		SELECT to_char(a.published_at AT TIME ZONE 'UTC', 'YYYY-MM') AS value, COUNT(*) AS count
+		FROM articles a` + where + `
+		  AND a.published_at IS NOT NULL
+		GROUP BY value
+		ORDER BY value DESC
+	`
+	facets.Months = []FacetBucket{}
+	if err := r.db.Select(&facets.Months, query, args...); err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+// parseSearchDate parses a publication date filter, either a day or a month. A month
+// starts on its first day when it opens a range and ends on its last day when it closes one.
+func parseSearchDate(value string, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(statsDateLayout, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(searchMonthLayout, value)
+	if err != nil {
+		return time.Time{}, ErrInvalidSearchFilter
+	}
+	if end {
+		t = t.AddDate(0, 1, -1)
+	}
+	return t, nil
+}
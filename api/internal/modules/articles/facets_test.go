@@ -0,0 +1,74 @@
+package articles
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSearchDate(t *testing.T) {
+	d, err := parseSearchDate("", false)
+	require.NoError(t, err)
+	assert.True(t, d.IsZero())
+
+	d, err = parseSearchDate("2025-03-10", true)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), d)
+
+	d, err = parseSearchDate("2024-02", false)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), d)
+
+	d, err = parseSearchDate("2024-02", true)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), d)
+
+	_, err = parseSearchDate("last week", false)
+	assert.ErrorIs(t, err, ErrInvalidSearchFilter)
+}
+
+func TestSearchWhere(t *testing.T) {
+	opts := SearchOptions{
+		Query:         "go",
+		CategoryIDs:   []string{"123e4567-e89b-12d3-a456-426614174000"},
+		Tags:          []string{"go", "gc"},
+		PublishedFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		PublishedTo:   time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	where, args := searchWhere(opts, filterNone)
+	assert.Len(t, args, 5)
+	assert.Contains(t, where, "a.category_id = ANY($2::uuid[])")
+	assert.Contains(t, where, "EXISTS")
+	assert.Contains(t, where, "a.published_at < $5")
+	// The end day is inclusive
+	assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), args[4])
+
+	where, args = searchWhere(opts, filterCategories)
+	assert.Len(t, args, 4)
+	assert.NotContains(t, where, "category_id")
+
+	opts.MatchAllTags = true
+	where, _ = searchWhere(opts, filterPublished)
+	assert.Contains(t, where, "cardinality")
+	assert.NotContains(t, where, "published_at")
+}
+
+func TestService_Search_Filters(t *testing.T) {
+	service := &Service{}
+	_, err := service.Search(context.Background(), SearchOptions{Query: "a"})
+	assert.ErrorIs(t, err, ErrQueryTooShort)
+
+	_, err = service.Search(context.Background(), SearchOptions{Query: "agents", CategoryIDs: []string{"news"}})
+	assert.ErrorIs(t, err, ErrInvalidSearchFilter)
+
+	_, err = service.Search(context.Background(), SearchOptions{
+		Query:         "agents",
+		PublishedFrom: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		PublishedTo:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.ErrorIs(t, err, ErrInvalidSearchFilter)
+}
@@ -2,48 +2,142 @@ package articles
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
+	"net"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ai-dala/api/internal/auth"
 )
 
 type Handler struct {
-	service *Service
+	service  *Service
+	verifier *auth.Verifier
 }
 
-func NewHandler(service *Service) *Handler {
-	return &Handler{service: service}
+func NewHandler(service *Service, verifier *auth.Verifier) *Handler {
+	return &Handler{service: service, verifier: verifier}
 }
 
 // RegisterRoutes registers all article routes
 func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
-	mux.HandleFunc("GET /api/articles", h.handleList)
-	mux.HandleFunc("GET /api/articles/public", h.handlePublicList)
-	mux.HandleFunc("GET /api/articles/{id}", h.handleGet)
-	mux.Handle("POST /api/articles", auth.Middleware(http.HandlerFunc(h.handleCreate)))
-	mux.Handle("PUT /api/articles/{id}", auth.Middleware(http.HandlerFunc(h.handleUpdate)))
-	mux.Handle("POST /api/articles/{id}/publish", auth.Middleware(http.HandlerFunc(h.handlePublish)))
-	mux.Handle("DELETE /api/articles/{id}", auth.Middleware(http.HandlerFunc(h.handleDelete)))
-	mux.HandleFunc("GET /api/articles-by-slug/{slug}", h.handleGetBySlug)
-	mux.Handle("POST /api/articles/{id}/tags", auth.Middleware(http.HandlerFunc(h.handleAddTags)))
-	mux.Handle("DELETE /api/articles/{id}/tags", auth.Middleware(http.HandlerFunc(h.handleRemoveTags)))
+	// Read routes are public; a valid token adds the caller's own reaction to the response
+	mux.Handle("GET /api/articles", h.verifier.OptionalMiddleware(http.HandlerFunc(h.handleList)))
+	mux.Handle("GET /api/articles/public", h.verifier.OptionalMiddleware(http.HandlerFunc(h.handlePublicList)))
+	mux.Handle("GET /api/articles/{id}", h.verifier.OptionalMiddleware(http.HandlerFunc(h.handleGet)))
+	mux.Handle("GET /api/articles/trending", h.verifier.OptionalMiddleware(http.HandlerFunc(h.handleTrending)))
+	mux.HandleFunc("GET /api/articles/{id}/related", h.handleRelated)
+	// Creating requires the content permission; other write routes require authentication
+	// and leave ownership and roles to the service policy
+	mux.Handle("POST /api/articles", h.verifier.Middleware(auth.RequirePermission(auth.PermManageContent)(http.HandlerFunc(h.handleCreate))))
+	mux.Handle("PUT /api/articles/{id}", h.verifier.Middleware(http.HandlerFunc(h.handleUpdate)))
+	mux.Handle("POST /api/articles/{id}/publish", h.verifier.Middleware(h.handleTransition(TransitionPublish)))
+	mux.Handle("PUT /api/articles/{id}/schedule", h.verifier.Middleware(http.HandlerFunc(h.handleSchedule)))
+	mux.Handle("DELETE /api/articles/{id}", h.verifier.Middleware(http.HandlerFunc(h.handleDelete)))
+	mux.Handle("GET /api/articles-by-slug/{slug}", h.verifier.OptionalMiddleware(http.HandlerFunc(h.handleGetBySlug)))
+	mux.Handle("POST /api/articles/{id}/tags", h.verifier.Middleware(http.HandlerFunc(h.handleAddTags)))
+	mux.Handle("DELETE /api/articles/{id}/tags", h.verifier.Middleware(http.HandlerFunc(h.handleRemoveTags)))
+
+	// Revision history routes
+	mux.Handle("GET /api/articles/{id}/revisions", h.verifier.Middleware(http.HandlerFunc(h.handleListRevisions)))
+	mux.Handle("GET /api/articles/{id}/revisions/diff", h.verifier.Middleware(http.HandlerFunc(h.handleDiffRevisions)))
+	mux.Handle("GET /api/articles/{id}/revisions/{number}", h.verifier.Middleware(http.HandlerFunc(h.handleGetRevision)))
+	mux.Handle("POST /api/articles/{id}/revisions/{number}/restore", h.verifier.Middleware(http.HandlerFunc(h.handleRestoreRevision)))
+
+	// Review workflow routes
+	mux.Handle("POST /api/articles/{id}/submit", h.verifier.Middleware(h.handleTransition(TransitionSubmit)))
+	mux.Handle("POST /api/articles/{id}/approve", h.verifier.Middleware(h.handleTransition(TransitionApprove)))
+	mux.Handle("POST /api/articles/{id}/request-changes", h.verifier.Middleware(h.handleTransition(TransitionRequestChanges)))
+	mux.Handle("POST /api/articles/{id}/archive", h.verifier.Middleware(h.handleTransition(TransitionArchive)))
+	mux.Handle("GET /api/articles/{id}/transitions", h.verifier.Middleware(http.HandlerFunc(h.handleListTransitions)))
+	mux.Handle("GET /api/articles/{id}/review-notes", h.verifier.Middleware(http.HandlerFunc(h.handleListReviewNotes)))
+	mux.Handle("POST /api/articles/{id}/review-notes", h.verifier.Middleware(http.HandlerFunc(h.handleAddReviewNote)))
 
 	// Interaction routes
-	mux.Handle("POST /api/articles/{id}/comments", auth.Middleware(http.HandlerFunc(h.handleAddComment)))
+	mux.Handle("POST /api/articles/{id}/comments", h.verifier.Middleware(http.HandlerFunc(h.handleAddComment)))
 	mux.HandleFunc("GET /api/articles/{id}/comments", h.handleGetComments)
-	mux.Handle("POST /api/articles/{id}/like", auth.Middleware(http.HandlerFunc(h.handleAddLike)))
-	mux.Handle("DELETE /api/articles/{id}/like", auth.Middleware(http.HandlerFunc(h.handleRemoveLike)))
+	mux.HandleFunc("GET /api/articles/{id}/comments/{commentID}/replies", h.handleGetReplies)
+	mux.Handle("PUT /api/articles/{id}/comments/{commentID}", h.verifier.Middleware(http.HandlerFunc(h.handleUpdateComment)))
+	mux.Handle("DELETE /api/articles/{id}/comments/{commentID}", h.verifier.Middleware(http.HandlerFunc(h.handleDeleteComment)))
+	mux.Handle("GET /api/articles/{id}/comments/{commentID}/edits", h.verifier.Middleware(http.HandlerFunc(h.handleListCommentEdits)))
+	mux.Handle("POST /api/articles/{id}/like", h.verifier.Middleware(http.HandlerFunc(h.handleAddLike)))
+	mux.Handle("DELETE /api/articles/{id}/like", h.verifier.Middleware(http.HandlerFunc(h.handleRemoveLike)))
 	mux.HandleFunc("GET /api/articles/{id}/interactions", h.handleGetInteractions)
 
+	// View tracking and analytics routes
+	mux.Handle("POST /api/articles/{id}/view", h.verifier.OptionalMiddleware(http.HandlerFunc(h.handleRecordView)))
+	mux.Handle("GET /api/articles/{id}/stats", h.verifier.Middleware(http.HandlerFunc(h.handleGetStats)))
+
+	// Bookmark routes
+	mux.Handle("POST /api/articles/{id}/bookmark", h.verifier.Middleware(http.HandlerFunc(h.handleAddBookmark)))
+	mux.Handle("DELETE /api/articles/{id}/bookmark", h.verifier.Middleware(http.HandlerFunc(h.handleRemoveBookmark)))
+	mux.Handle("GET /api/user/bookmarks", h.verifier.Middleware(http.HandlerFunc(h.handleListBookmarks)))
+	mux.Handle("GET /api/user/bookmark-collections", h.verifier.Middleware(http.HandlerFunc(h.handleListCollections)))
+	mux.Handle("POST /api/user/bookmark-collections", h.verifier.Middleware(http.HandlerFunc(h.handleCreateCollection)))
+	mux.Handle("DELETE /api/user/bookmark-collections/{collectionID}", h.verifier.Middleware(http.HandlerFunc(h.handleDeleteCollection)))
+
+	// Comment moderation routes
+	mux.Handle("GET /api/comments/moderation", h.verifier.Middleware(http.HandlerFunc(h.handleModerationQueue)))
+	mux.Handle("POST /api/comments/{commentID}/approve", h.verifier.Middleware(http.HandlerFunc(h.handleApproveComment)))
+	mux.Handle("POST /api/comments/{commentID}/reject", h.verifier.Middleware(http.HandlerFunc(h.handleRejectComment)))
+
 	// Search route
-	mux.HandleFunc("GET /api/articles/search", h.handleSearch)
+	mux.Handle("GET /api/articles/search", h.verifier.OptionalMiddleware(http.HandlerFunc(h.handleSearch)))
+	mux.HandleFunc("GET /api/search/suggest", h.handleSuggest)
+	mux.Handle("POST /api/search/clicks", h.verifier.OptionalMiddleware(http.HandlerFunc(h.handleSearchClick)))
+
+	// Search analytics routes
+	mux.Handle("GET /api/search/analytics/top-queries", h.verifier.Middleware(h.handleSearchAnalytics(SearchReportTopQueries)))
+	mux.Handle("GET /api/search/analytics/zero-results", h.verifier.Middleware(h.handleSearchAnalytics(SearchReportZeroResults)))
+	mux.Handle("GET /api/search/analytics/click-through", h.verifier.Middleware(h.handleSearchAnalytics(SearchReportClickThrough)))
 
 	// Test route for creating articles without auth (for E2E tests)
 	mux.HandleFunc("POST /api/test/articles", h.handleCreateTest)
 }
 
-// handleList lists all articles with filters
+// authorize checks the article policy for the caller and writes an error response on failure
+func (h *Handler) authorize(w http.ResponseWriter, r *http.Request, id string, action Action) (*Article, bool) {
+	actor, err := ActorFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return nil, false
+	}
+	article, err := h.service.AuthorizeArticle(actor, id, action)
+	if err != nil {
+		writeServiceError(w, err)
+		return nil, false
+	}
+	return article, true
+}
+
+// writeServiceError maps service errors to HTTP status codes
+func writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, ErrInvalidSchedule), errors.Is(err, ErrInvalidStatus),
+		errors.Is(err, ErrInvalidCursor), errors.Is(err, ErrCommentTooDeep), errors.Is(err, ErrEmptyComment),
+		errors.Is(err, ErrInvalidCollection), errors.Is(err, ErrInvalidStatsRange), errors.Is(err, ErrInvalidWindow),
+		errors.Is(err, ErrInvalidLanguage), errors.Is(err, ErrQueryTooShort), errors.Is(err, ErrInvalidSearchFilter),
+		errors.Is(err, ErrInvalidSearchMode), errors.Is(err, ErrInvalidSearchReport), errors.Is(err, ErrDiffTooLarge):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrInvalidTransition), errors.Is(err, ErrDuplicateCollection):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrArticleNotFound), errors.Is(err, ErrCommentNotFound), errors.Is(err, ErrRevisionNotFound),
+		errors.Is(err, ErrCollectionNotFound), errors.Is(err, ErrSearchNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrSemanticSearchUnavailable):
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// handleList lists all articles with filters.
+// status=SCHEDULED lists articles with an upcoming publish or unpublish time.
 func (h *Handler) handleList(w http.ResponseWriter, r *http.Request) {
 	status := r.URL.Query().Get("status")
 	categoryID := r.URL.Query().Get("category_id")
@@ -77,9 +171,11 @@ func (h *Handler) handleList(w http.ResponseWriter, r *http.Request) {
 	// Get tags for each article
 	type ArticleWithTags struct {
 		Article
-		Tags []string `json:"tags"`
+		Tags         []string `json:"tags"`
+		UserReaction *string  `json:"user_reaction"`
 	}
 
+	reactions := h.userReactions(r, articles)
 	articlesWithTags := make([]ArticleWithTags, len(articles))
 	for i, article := range articles {
 		tags, err := h.service.GetTags(article.ID)
@@ -87,8 +183,9 @@ func (h *Handler) handleList(w http.ResponseWriter, r *http.Request) {
 			tags = []string{}
 		}
 		articlesWithTags[i] = ArticleWithTags{
-			Article: article,
-			Tags:    tags,
+			Article:      article,
+			Tags:         tags,
+			UserReaction: reactionOf(reactions, article.ID),
 		}
 	}
 
@@ -134,9 +231,11 @@ func (h *Handler) handlePublicList(w http.ResponseWriter, r *http.Request) {
 	// Get tags for each article
 	type ArticleWithTags struct {
 		Article
-		Tags []string `json:"tags"`
+		Tags         []string `json:"tags"`
+		UserReaction *string  `json:"user_reaction"`
 	}
 
+	reactions := h.userReactions(r, articles)
 	articlesWithTags := make([]ArticleWithTags, len(articles))
 	for i, article := range articles {
 		tags, err := h.service.GetTags(article.ID)
@@ -144,8 +243,9 @@ func (h *Handler) handlePublicList(w http.ResponseWriter, r *http.Request) {
 			tags = []string{}
 		}
 		articlesWithTags[i] = ArticleWithTags{
-			Article: article,
-			Tags:    tags,
+			Article:      article,
+			Tags:         tags,
+			UserReaction: reactionOf(reactions, article.ID),
 		}
 	}
 
@@ -160,6 +260,87 @@ func (h *Handler) handlePublicList(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// handleTrending lists the articles with the most recent reader activity.
+// Query params: window (24h, 7d or 30d; default 7d), limit (default 10, max 50).
+func (h *Handler) handleTrending(w http.ResponseWriter, r *http.Request) {
+	window := r.URL.Query().Get("window")
+	limit := 10
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 && l <= 50 {
+		limit = l
+	}
+
+	trending, err := h.service.Trending(window, limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	if window == "" {
+		window = DefaultTrendingWindow
+	}
+
+	type TrendingWithTags struct {
+		TrendingArticle
+		Tags         []string `json:"tags"`
+		UserReaction *string  `json:"user_reaction"`
+	}
+
+	articles := make([]Article, len(trending))
+	for i, t := range trending {
+		articles[i] = t.Article
+	}
+	reactions := h.userReactions(r, articles)
+	items := make([]TrendingWithTags, len(trending))
+	for i, t := range trending {
+		tags, err := h.service.GetTags(t.ID)
+		if err != nil {
+			tags = []string{}
+		}
+		items[i] = TrendingWithTags{
+			TrendingArticle: t,
+			Tags:            tags,
+			UserReaction:    reactionOf(reactions, t.ID),
+		}
+	}
+
+	response := map[string]interface{}{
+		"articles": items,
+		"window":   window,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleRelated recommends published articles to read after this one.
+// Query params: limit (default from RELATED_ARTICLES_LIMIT, max 20).
+func (h *Handler) handleRelated(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	related, err := h.service.Related(r.PathValue("id"), limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"articles": related})
+}
+
+// ListPopularTags lists tags of published articles, those with the most recent activity first.
+// It serves GET /api/tags?popular=true. Query params: limit.
+func (h *Handler) ListPopularTags(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	tags, err := h.service.GetTagsWithCounts(true, limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"tags": tags})
+}
+
 // handleGet retrieves a single article
 func (h *Handler) handleGet(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
@@ -181,8 +362,9 @@ func (h *Handler) handleGet(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := map[string]interface{}{
-		"article": article,
-		"tags":    tags,
+		"article":       article,
+		"tags":          tags,
+		"user_reaction": reactionOf(h.userReactions(r, []Article{*article}), article.ID),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -210,20 +392,48 @@ func (h *Handler) handleGetBySlug(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := map[string]interface{}{
-		"article": article,
-		"tags":    tags,
+		"article":       article,
+		"tags":          tags,
+		"user_reaction": reactionOf(h.userReactions(r, []Article{*article}), article.ID),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
+// userReactions looks up the authenticated caller's reactions to the articles.
+// Anonymous callers, and lookup failures, yield no reactions.
+func (h *Handler) userReactions(r *http.Request, articles []Article) map[string]string {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return nil
+	}
+	ids := make([]string, len(articles))
+	for i, a := range articles {
+		ids[i] = a.ID
+	}
+	reactions, err := h.service.GetUserReactions(ids, userID)
+	if err != nil {
+		return nil
+	}
+	return reactions
+}
+
+// reactionOf returns the reaction to an article, or nil to encode as JSON null
+func reactionOf(reactions map[string]string, articleID string) *string {
+	if reaction, ok := reactions[articleID]; ok {
+		return &reaction
+	}
+	return nil
+}
+
 // handleCreate creates a new article
 func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Title      string   `json:"title"`
 		Body       string   `json:"body"`
 		CategoryID *string  `json:"category_id"`
+		Language   string   `json:"language"`
 		TagIDs     []string `json:"tag_ids"`
 	}
 
@@ -244,10 +454,11 @@ func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
 		CategoryID: req.CategoryID,
 		AuthorID:   authorID,
 		Status:     "DRAFT",
+		Language:   req.Language,
 	}
 
 	if err := h.service.Create(article); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, err)
 		return
 	}
 
@@ -284,6 +495,7 @@ func (h *Handler) handleUpdate(w http.ResponseWriter, r *http.Request) {
 		Body       string   `json:"body"`
 		CategoryID *string  `json:"category_id"`
 		Status     string   `json:"status"`
+		Language   string   `json:"language"`
 		TagIDs     []string `json:"tag_ids"`
 	}
 
@@ -292,15 +504,31 @@ func (h *Handler) handleUpdate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	actor, err := ActorFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	current, ok := h.authorize(w, r, id, ActionEdit)
+	if !ok {
+		return
+	}
+	// Status only changes through the workflow endpoints
+	if req.Status != "" && req.Status != current.Status {
+		http.Error(w, "status changes must use the workflow endpoints", http.StatusConflict)
+		return
+	}
+
 	article := &Article{
 		Title:      req.Title,
 		Body:       req.Body,
 		CategoryID: req.CategoryID,
-		Status:     req.Status,
+		Language:   req.Language,
 	}
 
-	if err := h.service.Update(id, article); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := h.service.Update(id, article, actor); err != nil {
+		writeServiceError(w, err)
 		return
 	}
 
@@ -342,21 +570,152 @@ func (h *Handler) handleUpdate(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-// handlePublish publishes an article
-func (h *Handler) handlePublish(w http.ResponseWriter, r *http.Request) {
+// handleTransition moves an article through the review workflow.
+// An optional {"note": "..."} body is stored with the transition and as a reviewer note.
+func (h *Handler) handleTransition(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+
+		actor, err := ActorFromContext(r.Context())
+		if err != nil {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		var req struct {
+			Note string `json:"note"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		article, err := h.service.Transition(actor, id, name, req.Note)
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+
+		// Get tags for response
+		tags, err := h.service.GetTags(id)
+		if err != nil {
+			tags = []string{}
+		}
+
+		response := map[string]interface{}{
+			"article": article,
+			"tags":    tags,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// handleListTransitions lists who moved an article between workflow states and when
+func (h *Handler) handleListTransitions(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if _, ok := h.authorize(w, r, id, ActionView); !ok {
+		return
+	}
+
+	history, err := h.service.ListTransitions(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"transitions": history,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleListReviewNotes lists the private reviewer notes of an article
+func (h *Handler) handleListReviewNotes(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
-	if err := h.service.Publish(id); err != nil {
+	if _, ok := h.authorize(w, r, id, ActionView); !ok {
+		return
+	}
+
+	notes, err := h.service.ListReviewNotes(id)
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	article, err := h.service.FindByID(id)
+	response := map[string]interface{}{
+		"notes": notes,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleAddReviewNote adds a private reviewer note to an article
+func (h *Handler) handleAddReviewNote(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var req struct {
+		Body string `json:"body"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		http.Error(w, "note body cannot be empty", http.StatusBadRequest)
+		return
+	}
+
+	actor, err := ActorFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if _, ok := h.authorize(w, r, id, ActionView); !ok {
+		return
+	}
+
+	note, err := h.service.AddReviewNote(id, actor.UserID, req.Body)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(note)
+}
+
+// handleSchedule sets or clears the scheduled publish and unpublish times of an article
+func (h *Handler) handleSchedule(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var req struct {
+		PublishAt   *time.Time `json:"publish_at"`
+		UnpublishAt *time.Time `json:"unpublish_at"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := h.authorize(w, r, id, ActionPublish); !ok {
+		return
+	}
+
+	article, err := h.service.Schedule(id, req.PublishAt, req.UnpublishAt)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
 	// Get tags for response
 	tags, err := h.service.GetTags(id)
 	if err != nil {
@@ -375,6 +734,10 @@ func (h *Handler) handlePublish(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) handleDelete(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
+	if _, ok := h.authorize(w, r, id, ActionDelete); !ok {
+		return
+	}
+
 	if err := h.service.Delete(id); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -396,6 +759,10 @@ func (h *Handler) handleAddTags(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, ok := h.authorize(w, r, id, ActionEdit); !ok {
+		return
+	}
+
 	if err := h.service.AddTags(id, req.TagIDs); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -417,6 +784,10 @@ func (h *Handler) handleRemoveTags(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, ok := h.authorize(w, r, id, ActionEdit); !ok {
+		return
+	}
+
 	if err := h.service.RemoveTags(id, req.TagIDs); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -425,117 +796,596 @@ func (h *Handler) handleRemoveTags(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// handleAddComment adds a comment to an article
-func (h *Handler) handleAddComment(w http.ResponseWriter, r *http.Request) {
+// handleListRevisions lists the revision history of an article
+func (h *Handler) handleListRevisions(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
-	userID, err := auth.GetUserIDFromContext(r.Context())
-	if err != nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
 
-	var req struct {
-		Body string `json:"body"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if _, ok := h.authorize(w, r, id, ActionEdit); !ok {
 		return
 	}
 
-	comment, err := h.service.AddComment(id, userID, req.Body)
+	revisions, err := h.service.ListRevisions(id)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	response := map[string]interface{}{
+		"revisions": revisions,
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(comment)
+	json.NewEncoder(w).Encode(response)
 }
 
-// handleGetComments retrieves comments for an article
-func (h *Handler) handleGetComments(w http.ResponseWriter, r *http.Request) {
+// handleGetRevision retrieves a single revision including its body
+func (h *Handler) handleGetRevision(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
-
-	comments, err := h.service.GetComments(id)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil || number < 1 {
+		http.Error(w, "invalid revision number", http.StatusBadRequest)
 		return
 	}
-	if comments == nil {
-		comments = []Comment{}
+
+	if _, ok := h.authorize(w, r, id, ActionEdit); !ok {
+		return
 	}
 
-	response := map[string]interface{}{
-		"comments": comments,
+	revision, err := h.service.GetRevision(id, number)
+	if err != nil {
+		writeServiceError(w, err)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	json.NewEncoder(w).Encode(revision)
 }
 
-// handleAddLike adds a like/dislike
-func (h *Handler) handleAddLike(w http.ResponseWriter, r *http.Request) {
+// handleDiffRevisions shows a line-level diff of the body between two revisions
+func (h *Handler) handleDiffRevisions(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
-	userID, err := auth.GetUserIDFromContext(r.Context())
-	if err != nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	from, errFrom := strconv.Atoi(r.URL.Query().Get("from"))
+	to, errTo := strconv.Atoi(r.URL.Query().Get("to"))
+	if errFrom != nil || errTo != nil || from < 1 || to < 1 {
+		http.Error(w, "query parameters 'from' and 'to' must be revision numbers", http.StatusBadRequest)
 		return
 	}
 
-	var req struct {
-		IsLike bool `json:"is_like"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if _, ok := h.authorize(w, r, id, ActionEdit); !ok {
 		return
 	}
 
-	if err := h.service.AddLike(id, userID, req.IsLike); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	diff, err := h.service.DiffRevisions(id, from, to)
+	if err != nil {
+		writeServiceError(w, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	response := map[string]interface{}{
+		"from": from,
+		"to":   to,
+		"diff": diff,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
 }
 
-// handleRemoveLike removes a like/dislike
-func (h *Handler) handleRemoveLike(w http.ResponseWriter, r *http.Request) {
+// handleRestoreRevision restores a revision as the current version of the article
+func (h *Handler) handleRestoreRevision(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
-	userID, err := auth.GetUserIDFromContext(r.Context())
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil || number < 1 {
+		http.Error(w, "invalid revision number", http.StatusBadRequest)
+		return
+	}
+
+	actor, err := ActorFromContext(r.Context())
 	if err != nil {
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
 		return
 	}
 
-	if err := h.service.RemoveLike(id, userID); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if _, ok := h.authorize(w, r, id, ActionEdit); !ok {
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
-}
-
-// handleGetInteractions retrieves interaction stats
-func (h *Handler) handleGetInteractions(w http.ResponseWriter, r *http.Request) {
-	id := r.PathValue("id")
-
-	likes, dislikes, err := h.service.GetLikesCount(id)
+	article, err := h.service.RestoreRevision(id, number, actor)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, err)
 		return
 	}
 
+	// Get tags for response
+	tags, err := h.service.GetTags(id)
+	if err != nil {
+		tags = []string{}
+	}
+
 	response := map[string]interface{}{
-		"likes":    likes,
-		"dislikes": dislikes,
+		"article": article,
+		"tags":    tags,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
-// handleSearch performs full-text search on articles
+// handleAddComment adds a comment to an article, or a reply when parent_id is given
+func (h *Handler) handleAddComment(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Body     string  `json:"body"`
+		ParentID *string `json:"parent_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	comment, err := h.service.AddComment(id, userID, req.Body, req.ParentID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(comment)
+}
+
+// handleGetComments retrieves a page of top-level comments for an article.
+// Query params: cursor (from next_cursor of the previous page), limit.
+func (h *Handler) handleGetComments(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	page, err := h.service.ListComments(id, r.URL.Query().Get("cursor"), commentLimit(r))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// handleGetReplies retrieves a page of direct replies to a comment
+func (h *Handler) handleGetReplies(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	commentID := r.PathValue("commentID")
+
+	page, err := h.service.ListReplies(id, commentID, r.URL.Query().Get("cursor"), commentLimit(r))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// handleUpdateComment edits a comment; allowed for its author and moderators
+func (h *Handler) handleUpdateComment(w http.ResponseWriter, r *http.Request) {
+	actor, err := ActorFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Body string `json:"body"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	comment, err := h.service.UpdateComment(actor, r.PathValue("id"), r.PathValue("commentID"), req.Body)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(comment)
+}
+
+// handleDeleteComment soft deletes a comment; allowed for its author and moderators
+func (h *Handler) handleDeleteComment(w http.ResponseWriter, r *http.Request) {
+	actor, err := ActorFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.DeleteComment(actor, r.PathValue("id"), r.PathValue("commentID")); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleListCommentEdits lists the previous bodies of a comment (moderators only)
+func (h *Handler) handleListCommentEdits(w http.ResponseWriter, r *http.Request) {
+	actor, err := ActorFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	edits, err := h.service.ListCommentEdits(actor, r.PathValue("id"), r.PathValue("commentID"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"edits": edits})
+}
+
+// handleModerationQueue lists comments awaiting or past moderation.
+// Query params: status (PENDING by default, APPROVED, REJECTED, SPAM), limit, page.
+func (h *Handler) handleModerationQueue(w http.ResponseWriter, r *http.Request) {
+	actor, err := ActorFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit := clampCommentLimit(commentLimit(r))
+	page := 1
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	comments, total, err := h.service.ListModerationQueue(actor, r.URL.Query().Get("status"), limit, (page-1)*limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"comments": comments,
+		"total":    total,
+		"page":     page,
+		"limit":    limit,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleApproveComment publishes a held or rejected comment
+func (h *Handler) handleApproveComment(w http.ResponseWriter, r *http.Request) {
+	actor, err := ActorFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	comment, err := h.service.ModerateCommentAs(actor, r.PathValue("commentID"), CommentApproved, nil)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(comment)
+}
+
+// handleRejectComment hides a comment, optionally marking it as spam.
+// Body: {"reason": "...", "spam": true}; both fields are optional.
+func (h *Handler) handleRejectComment(w http.ResponseWriter, r *http.Request) {
+	actor, err := ActorFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Reason *string `json:"reason"`
+		Spam   bool    `json:"spam"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	status := CommentRejected
+	if req.Spam {
+		status = CommentSpam
+	}
+	comment, err := h.service.ModerateCommentAs(actor, r.PathValue("commentID"), status, req.Reason)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(comment)
+}
+
+// commentLimit reads the limit query param; the service applies defaults and bounds
+func commentLimit(r *http.Request) int {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	return limit
+}
+
+// handleAddLike adds a like/dislike
+func (h *Handler) handleAddLike(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		IsLike bool `json:"is_like"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.AddLike(id, userID, req.IsLike); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleRemoveLike removes a like/dislike
+func (h *Handler) handleRemoveLike(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RemoveLike(id, userID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleGetInteractions retrieves interaction stats
+func (h *Handler) handleGetInteractions(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	likes, dislikes, err := h.service.GetLikesCount(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"likes":    likes,
+		"dislikes": dislikes,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleAddBookmark saves an article to the caller's reading list.
+// Body (optional): {"collection_id": "..."}; bookmarking a saved article moves it to that collection.
+func (h *Handler) handleAddBookmark(w http.ResponseWriter, r *http.Request) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		CollectionID *string `json:"collection_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.AddBookmark(userID, r.PathValue("id"), req.CollectionID); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleRemoveBookmark removes an article from the caller's reading list
+func (h *Handler) handleRemoveBookmark(w http.ResponseWriter, r *http.Request) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RemoveBookmark(userID, r.PathValue("id")); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleListBookmarks lists the caller's saved articles, most recently saved first.
+// Query params: collection_id, page, limit.
+func (h *Handler) handleListBookmarks(w http.ResponseWriter, r *http.Request) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	limit := 20
+	if l, err := strconv.Atoi(query.Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	page := 1
+	if p, err := strconv.Atoi(query.Get("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	bookmarks, total, err := h.service.ListBookmarks(userID, query.Get("collection_id"), limit, (page-1)*limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"bookmarks": bookmarks,
+		"total":     total,
+		"page":      page,
+		"limit":     limit,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleListCollections lists the caller's bookmark collections
+func (h *Handler) handleListCollections(w http.ResponseWriter, r *http.Request) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	collections, err := h.service.ListCollections(userID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"collections": collections})
+}
+
+// handleCreateCollection adds a bookmark collection.
+// Body: {"name": "..."}
+func (h *Handler) handleCreateCollection(w http.ResponseWriter, r *http.Request) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	collection, err := h.service.CreateCollection(userID, req.Name)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(collection)
+}
+
+// handleDeleteCollection removes a bookmark collection; its bookmarks stay on the reading list
+func (h *Handler) handleDeleteCollection(w http.ResponseWriter, r *http.Request) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.DeleteCollection(userID, r.PathValue("collectionID")); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleRecordView counts a view of a published article. The page calls it once rendered,
+// so crawlers that do not run scripts are not counted.
+func (h *Handler) handleRecordView(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.RecordView(r.PathValue("id"), h.visitorKey(r)); err != nil && !errors.Is(err, ErrArticleNotFound) {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// visitorKey identifies the caller for view counting: signed-in readers by user ID,
+// anonymous ones by client address and user agent
+func (h *Handler) visitorKey(r *http.Request) string {
+	if userID, err := auth.GetUserIDFromContext(r.Context()); err == nil {
+		return "user:" + userID
+	}
+	return "anon:" + clientIP(r, h.service.config.TrustedProxies) + "|" + r.UserAgent()
+}
+
+// clientIP returns the address of the client. Behind trusted proxies it is the
+// X-Forwarded-For entry added by the outermost of them; entries to its left come from the
+// client and are ignored. Otherwise, or when the header is too short, it is the peer address.
+func clientIP(r *http.Request, trustedProxies int) string {
+	if trustedProxies > 0 {
+		var hops []string
+		for _, header := range r.Header.Values("X-Forwarded-For") {
+			hops = append(hops, strings.Split(header, ",")...)
+		}
+		if len(hops) >= trustedProxies {
+			if hop := strings.TrimSpace(hops[len(hops)-trustedProxies]); hop != "" {
+				return hop
+			}
+		}
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// handleGetStats retrieves daily views, likes and comments of an article for its author or an editor.
+// Query params: from, to (YYYY-MM-DD, UTC; default the last 30 days).
+func (h *Handler) handleGetStats(w http.ResponseWriter, r *http.Request) {
+	actor, err := ActorFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	stats, err := h.service.GetStats(actor, r.PathValue("id"), query.Get("from"), query.Get("to"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
+
+// handleSearch performs full-text search on articles.
+// Query params: q (supports "phrases", -exclusions, OR and title:, body:, tag:, author: qualifiers;
+// author: takes a user ID, and any other value is searched as plain text),
+// category_id (repeatable), tags, tag_mode (any or all), from and to (YYYY-MM-DD or YYYY-MM), lang,
+// mode (keyword, semantic or hybrid; default keyword), page, limit.
+// The first page of each search is logged for search analytics; its search_id is sent back
+// with clicks on the results.
 func (h *Handler) handleSearch(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("q")
 	if query == "" {
@@ -543,8 +1393,21 @@ func (h *Handler) handleSearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	categoryID := r.URL.Query().Get("category_id")
+	categoryIDs := r.URL.Query()["category_id"]
 	tags := r.URL.Query()["tags"]
+	matchAllTags := r.URL.Query().Get("tag_mode") == "all"
+	lang := r.URL.Query().Get("lang")
+
+	from, err := parseSearchDate(r.URL.Query().Get("from"), false)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	to, err := parseSearchDate(r.URL.Query().Get("to"), true)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
 
 	limitStr := r.URL.Query().Get("limit")
 	limit := 10
@@ -563,24 +1426,127 @@ func (h *Handler) handleSearch(w http.ResponseWriter, r *http.Request) {
 	}
 	offset := (page - 1) * limit
 
-	results, total, err := h.service.Search(query, categoryID, tags, limit, offset)
+	result, err := h.service.Search(r.Context(), SearchOptions{
+		Query:         query,
+		CategoryIDs:   categoryIDs,
+		Tags:          tags,
+		MatchAllTags:  matchAllTags,
+		Language:      lang,
+		PublishedFrom: from,
+		PublishedTo:   to,
+		Mode:          r.URL.Query().Get("mode"),
+		Limit:         limit,
+		Offset:        offset,
+	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, err)
 		return
 	}
+	total := result.Total
+
+	// Offer a spelling correction only when nothing matched; a failed lookup just omits it
+	var didYouMean *string
+	if total == 0 {
+		if correction, err := h.service.DidYouMean(query); err == nil && correction != "" {
+			didYouMean = &correction
+		}
+	}
+
+	// Log a search once rather than per page; a failed log must not fail the search
+	var searchID *string
+	if page == 1 {
+		if id, err := h.service.LogSearch(query, total, result.Mode, h.visitorKey(r)); err == nil && id != "" {
+			searchID = &id
+		}
+	}
 
 	response := map[string]interface{}{
-		"articles": results,
-		"total":    total,
-		"page":     page,
-		"limit":    limit,
-		"query":    query,
+		"search_id":    searchID,
+		"articles":     result.Results,
+		"total":        total,
+		"page":         page,
+		"limit":        limit,
+		"query":        query,
+		"did_you_mean": didYouMean,
+		"facets":       result.Facets,
+		"mode":         result.Mode,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
+// handleSuggest completes text typed into the search box.
+// Query params: q, lang (labels of tags and categories, default en), limit (per group, default 5, max 10).
+func (h *Handler) handleSuggest(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		http.Error(w, "query parameter 'q' is required", http.StatusBadRequest)
+		return
+	}
+
+	limit := 0
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil {
+		limit = l
+	}
+
+	suggestions, err := h.service.Suggest(query, r.URL.Query().Get("lang"), limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(suggestions)
+}
+
+// handleSearchClick records that a reader opened a search result.
+// Body: search_id (from the search response), article_id, position (zero-based rank in the results).
+func (h *Handler) handleSearchClick(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		SearchID  string `json:"search_id"`
+		ArticleID string `json:"article_id"`
+		Position  int    `json:"position"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.RecordSearchClick(req.SearchID, req.ArticleID, req.Position); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleSearchAnalytics returns a search analytics report for editors.
+// Query params: from, to (YYYY-MM-DD, default the last 30 days), limit (default 20, max 100),
+// min_searches (click-through only, default 5).
+func (h *Handler) handleSearchAnalytics(report string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor, err := ActorFromContext(r.Context())
+		if err != nil {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		query := r.URL.Query()
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		minSearches, _ := strconv.Atoi(query.Get("min_searches"))
+
+		result, err := h.service.SearchAnalytics(actor, report, query.Get("from"), query.Get("to"), minSearches, limit)
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}
+}
+
 // handleCreateTest creates a new article without auth (for E2E tests)
 func (h *Handler) handleCreateTest(w http.ResponseWriter, r *http.Request) {
 	var req struct {
@@ -0,0 +1,163 @@
+package articles
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Comment moderation statuses
+const (
+	CommentPending  = "PENDING"
+	CommentApproved = "APPROVED"
+	CommentRejected = "REJECTED"
+	CommentSpam     = "SPAM"
+)
+
+// CommentCandidate is a new or edited comment together with what is known about its author
+type CommentCandidate struct {
+	Body   string
+	UserID string
+	// ApprovedCount is the number of the author's comments moderators have approved
+	ApprovedCount int
+	// RecentBodies are the author's other comments within the repeat window
+	RecentBodies []string
+}
+
+// CommentVerdict is the outcome of a filter; an empty Status lets the comment through
+type CommentVerdict struct {
+	Status string
+	Reason string
+}
+
+// CommentFilter inspects a comment and may hold it for review (PENDING) or mark it as SPAM
+type CommentFilter interface {
+	Check(c *CommentCandidate) CommentVerdict
+}
+
+// NewCommentFilters builds the filter chain enabled by the configuration
+func NewCommentFilters(cfg ModerationConfig) []CommentFilter {
+	var filters []CommentFilter
+	if cfg.RepeatWindow > 0 {
+		filters = append(filters, RepeatFilter{})
+	}
+	if cfg.MaxLinks > 0 {
+		filters = append(filters, LinkFilter{MaxLinks: cfg.MaxLinks})
+	}
+	if len(cfg.BlockedWords) > 0 {
+		filters = append(filters, NewBlockedWordsFilter(cfg.BlockedWords))
+	}
+	if cfg.MinApprovedComments > 0 {
+		filters = append(filters, NewCommenterFilter{MinApproved: cfg.MinApprovedComments})
+	}
+	return filters
+}
+
+// ModerateComment runs the filters in order. SPAM stops the chain; otherwise the first
+// PENDING verdict wins, and a comment no filter objects to is APPROVED.
+func ModerateComment(filters []CommentFilter, c *CommentCandidate) CommentVerdict {
+	var held *CommentVerdict
+	for _, f := range filters {
+		v := f.Check(c)
+		switch v.Status {
+		case CommentSpam:
+			return v
+		case CommentPending:
+			if held == nil {
+				held = &v
+			}
+		}
+	}
+	if held != nil {
+		return *held
+	}
+	return CommentVerdict{Status: CommentApproved}
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://|\bwww\.`)
+
+// LinkFilter holds comments with more than MaxLinks links
+type LinkFilter struct {
+	MaxLinks int
+}
+
+func (f LinkFilter) Check(c *CommentCandidate) CommentVerdict {
+	if n := len(linkPattern.FindAllStringIndex(c.Body, -1)); n > f.MaxLinks {
+		return CommentVerdict{Status: CommentPending, Reason: fmt.Sprintf("contains %d links", n)}
+	}
+	return CommentVerdict{}
+}
+
+// BlockedWordsFilter holds comments containing a blocked word of any configured language
+type BlockedWordsFilter struct {
+	exact    map[string]string // word -> language
+	prefixes map[string]string // prefix -> language
+}
+
+// NewBlockedWordsFilter builds the filter from word lists keyed by language code
+func NewBlockedWordsFilter(words map[string][]string) BlockedWordsFilter {
+	f := BlockedWordsFilter{exact: map[string]string{}, prefixes: map[string]string{}}
+	for lang, list := range words {
+		for _, w := range list {
+			w = strings.ToLower(strings.TrimSpace(w))
+			if prefix, ok := strings.CutSuffix(w, "*"); ok && prefix != "" {
+				f.prefixes[prefix] = lang
+			} else if w != "" {
+				f.exact[w] = lang
+			}
+		}
+	}
+	return f
+}
+
+func (f BlockedWordsFilter) Check(c *CommentCandidate) CommentVerdict {
+	for _, word := range commentWords(c.Body) {
+		if lang, ok := f.exact[word]; ok {
+			return CommentVerdict{Status: CommentPending, Reason: fmt.Sprintf("blocked word (%s)", lang)}
+		}
+		for prefix, lang := range f.prefixes {
+			if strings.HasPrefix(word, prefix) {
+				return CommentVerdict{Status: CommentPending, Reason: fmt.Sprintf("blocked word (%s)", lang)}
+			}
+		}
+	}
+	return CommentVerdict{}
+}
+
+// RepeatFilter marks a comment as spam when its author recently posted the same text
+type RepeatFilter struct{}
+
+func (RepeatFilter) Check(c *CommentCandidate) CommentVerdict {
+	body := normalizeComment(c.Body)
+	for _, previous := range c.RecentBodies {
+		if normalizeComment(previous) == body {
+			return CommentVerdict{Status: CommentSpam, Reason: "repeated comment"}
+		}
+	}
+	return CommentVerdict{}
+}
+
+// NewCommenterFilter holds comments from users without enough approved comments yet
+type NewCommenterFilter struct {
+	MinApproved int
+}
+
+func (f NewCommenterFilter) Check(c *CommentCandidate) CommentVerdict {
+	if c.ApprovedCount < f.MinApproved {
+		return CommentVerdict{Status: CommentPending, Reason: "new commenter"}
+	}
+	return CommentVerdict{}
+}
+
+// commentWords splits a comment into lower-cased words in any script
+func commentWords(body string) []string {
+	return strings.FieldsFunc(strings.ToLower(body), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// normalizeComment ignores case, punctuation and spacing when comparing comments
+func normalizeComment(body string) string {
+	return strings.Join(commentWords(body), " ")
+}
@@ -0,0 +1,90 @@
+package articles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkFilter(t *testing.T) {
+	f := LinkFilter{MaxLinks: 1}
+
+	assert.Empty(t, f.Check(&CommentCandidate{Body: "see https://example.com"}).Status)
+	assert.Equal(t, CommentPending, f.Check(&CommentCandidate{Body: "http://a.example and www.b.example"}).Status)
+}
+
+func TestBlockedWordsFilter(t *testing.T) {
+	f := NewBlockedWordsFilter(map[string][]string{
+		"en": {"casino*"},
+		"ru": {"казино"},
+		"kk": {"құмар*"},
+	})
+
+	tests := []struct {
+		body    string
+		blocked bool
+	}{
+		{"Great article, thanks!", false},
+		{"Best CASINOS online", true},
+		{"Лучшее казино!", true},
+		{"Құмарлық ойындар", true},
+		{"occasional typo", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			v := f.Check(&CommentCandidate{Body: tt.body})
+			if tt.blocked {
+				assert.Equal(t, CommentPending, v.Status)
+			} else {
+				assert.Empty(t, v.Status)
+			}
+		})
+	}
+}
+
+func TestRepeatFilter(t *testing.T) {
+	c := &CommentCandidate{Body: "Buy now!!", RecentBodies: []string{"something else", "buy   NOW"}}
+	assert.Equal(t, CommentSpam, RepeatFilter{}.Check(c).Status)
+
+	c.RecentBodies = []string{"buy later"}
+	assert.Empty(t, RepeatFilter{}.Check(c).Status)
+}
+
+func TestNewCommenterFilter(t *testing.T) {
+	f := NewCommenterFilter{MinApproved: 1}
+
+	assert.Equal(t, CommentPending, f.Check(&CommentCandidate{ApprovedCount: 0}).Status)
+	assert.Empty(t, f.Check(&CommentCandidate{ApprovedCount: 3}).Status)
+}
+
+func TestModerateComment(t *testing.T) {
+	filters := NewCommentFilters(ModerationConfig{
+		MaxLinks:            1,
+		BlockedWords:        map[string][]string{"en": {"viagra"}},
+		RepeatWindow:        time.Hour,
+		MinApprovedComments: 1,
+	})
+
+	t.Run("Approved", func(t *testing.T) {
+		v := ModerateComment(filters, &CommentCandidate{Body: "Nice read", ApprovedCount: 2})
+		assert.Equal(t, CommentApproved, v.Status)
+	})
+
+	t.Run("First reason wins", func(t *testing.T) {
+		v := ModerateComment(filters, &CommentCandidate{Body: "viagra", ApprovedCount: 0})
+		assert.Equal(t, CommentPending, v.Status)
+		assert.Equal(t, "blocked word (en)", v.Reason)
+	})
+
+	t.Run("Spam overrides pending", func(t *testing.T) {
+		v := ModerateComment(filters, &CommentCandidate{Body: "viagra", RecentBodies: []string{"Viagra"}})
+		assert.Equal(t, CommentSpam, v.Status)
+	})
+
+	t.Run("No filters", func(t *testing.T) {
+		v := ModerateComment(NewCommentFilters(ModerationConfig{}), &CommentCandidate{Body: "http://a http://b"})
+		assert.Equal(t, CommentApproved, v.Status)
+	})
+}
@@ -0,0 +1,70 @@
+package articles
+
+import (
+	"context"
+
+	"github.com/ai-dala/api/internal/auth"
+)
+
+// Action is an operation on an article that is subject to the policy
+type Action string
+
+const (
+	ActionView    Action = "view"
+	ActionEdit    Action = "edit"
+	ActionSubmit  Action = "submit"
+	ActionReview  Action = "review"
+	ActionPublish Action = "publish"
+	ActionDelete  Action = "delete"
+)
+
+// Actor is the caller on whose behalf an operation is performed
+type Actor struct {
+	UserID     string
+	CanManage  bool // may edit and delete any article
+	CanPublish bool // may review, publish and archive any article
+}
+
+// ActorFromContext builds an Actor from the authenticated request context
+func ActorFromContext(ctx context.Context) (Actor, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return Actor{}, err
+	}
+	return Actor{
+		UserID:     userID,
+		CanManage:  auth.HasPermission(ctx, auth.PermManageContent),
+		CanPublish: auth.HasPermission(ctx, auth.PermPublishContent),
+	}, nil
+}
+
+// IsModerator reports whether the actor may act on other users' comments
+func (a Actor) IsModerator() bool {
+	return a.CanManage
+}
+
+// CanArticle decides whether the actor may perform the action on the article.
+// Authors may edit and delete their own drafts (including ones sent back for changes)
+// and submit them for review; editors may do anything.
+func CanArticle(actor Actor, article *Article, action Action) bool {
+	isAuthor := article.AuthorID == actor.UserID
+
+	switch action {
+	case ActionReview, ActionPublish:
+		return actor.CanPublish
+	case ActionView, ActionSubmit:
+		return actor.CanManage || isAuthor
+	case ActionEdit, ActionDelete:
+		if actor.CanManage {
+			return true
+		}
+		return isAuthor && (article.Status == StatusDraft || article.Status == StatusChangesRequested)
+	}
+	return false
+}
+
+// CanComment decides whether the actor may edit or delete the comment.
+// Authors may change their own comments; moderators may change any comment.
+func CanComment(actor Actor, comment *Comment) bool {
+	return actor.IsModerator() || comment.UserID == actor.UserID
+}
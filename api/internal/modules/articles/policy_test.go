@@ -0,0 +1,74 @@
+package articles
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ai-dala/api/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanArticle(t *testing.T) {
+	author := Actor{UserID: "author-1"}
+	stranger := Actor{UserID: "someone-else"}
+	editor := Actor{UserID: "editor-1", CanManage: true, CanPublish: true}
+
+	draft := &Article{AuthorID: "author-1", Status: StatusDraft}
+	changesRequested := &Article{AuthorID: "author-1", Status: StatusChangesRequested}
+	inReview := &Article{AuthorID: "author-1", Status: StatusInReview}
+	published := &Article{AuthorID: "author-1", Status: StatusPublished}
+
+	tests := []struct {
+		name    string
+		actor   Actor
+		article *Article
+		action  Action
+		allowed bool
+	}{
+		{"Author edits own draft", author, draft, ActionEdit, true},
+		{"Author deletes own draft", author, draft, ActionDelete, true},
+		{"Author edits own article with changes requested", author, changesRequested, ActionEdit, true},
+		{"Author edits own article in review", author, inReview, ActionEdit, false},
+		{"Author edits own published article", author, published, ActionEdit, false},
+		{"Author submits own draft", author, draft, ActionSubmit, true},
+		{"Author views own article history", author, inReview, ActionView, true},
+		{"Author approves own article", author, inReview, ActionReview, false},
+		{"Stranger submits draft", stranger, draft, ActionSubmit, false},
+		{"Stranger views history", stranger, draft, ActionView, false},
+		{"Editor reviews article", editor, inReview, ActionReview, true},
+		{"Author publishes own draft", author, draft, ActionPublish, false},
+		{"Stranger edits draft", stranger, draft, ActionEdit, false},
+		{"Stranger deletes draft", stranger, draft, ActionDelete, false},
+		{"Editor edits published article", editor, published, ActionEdit, true},
+		{"Editor publishes draft", editor, draft, ActionPublish, true},
+		{"Editor deletes published article", editor, published, ActionDelete, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, CanArticle(tt.actor, tt.article, tt.action))
+		})
+	}
+}
+
+func TestCanComment(t *testing.T) {
+	comment := &Comment{UserID: "user-1"}
+
+	assert.True(t, CanComment(Actor{UserID: "user-1"}, comment))
+	assert.False(t, CanComment(Actor{UserID: "user-2"}, comment))
+	assert.True(t, CanComment(Actor{UserID: "editor-1", CanManage: true}, comment))
+}
+
+func TestActorFromContext(t *testing.T) {
+	_, err := ActorFromContext(context.Background())
+	assert.Error(t, err)
+
+	ctx := context.WithValue(context.Background(), auth.UserIDKey, "user-1")
+	ctx = context.WithValue(ctx, auth.RolesKey, []string{auth.RoleContentManager})
+	actor, err := ActorFromContext(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", actor.UserID)
+	assert.True(t, actor.CanManage)
+	assert.True(t, actor.CanPublish)
+}
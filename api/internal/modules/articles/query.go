@@ -0,0 +1,206 @@
+package articles
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Fields a search term can be scoped to with a field:value qualifier
+const (
+	fieldTitle  = "title"
+	fieldBody   = "body"
+	fieldTag    = "tag"
+	fieldAuthor = "author"
+)
+
+var searchFields = []string{fieldTitle, fieldBody, fieldTag, fieldAuthor}
+
+// queryToken is one term of a search query: a word or a quoted phrase, optionally negated
+// with a leading minus and scoped to a field, or the OR operator
+type queryToken struct {
+	Field   string
+	Value   string
+	Phrase  bool
+	Negated bool
+	Or      bool
+}
+
+// text renders the term in websearch_to_tsquery syntax, without its field
+func (t queryToken) text() string {
+	v := t.Value
+	if t.Phrase {
+		v = `"` + v + `"`
+	}
+	if t.Negated {
+		v = "-" + v
+	}
+	return v
+}
+
+// String renders the term as it is typed in a query
+func (t queryToken) String() string {
+	if t.Or {
+		return "OR"
+	}
+	if t.Field == "" {
+		return t.text()
+	}
+	v := t.Value
+	if t.Phrase {
+		v = `"` + v + `"`
+	}
+	v = t.Field + ":" + v
+	if t.Negated {
+		v = "-" + v
+	}
+	return v
+}
+
+// textual reports whether the term is matched against the article text
+func (t queryToken) textual() bool {
+	return !t.Or && (t.Field == "" || t.Field == fieldTitle || t.Field == fieldBody)
+}
+
+// tokenizeSearchQuery splits a query such as `"prompt engineering" -openai title:agents`
+// into terms. Unknown qualifiers are kept as plain words.
+func tokenizeSearchQuery(query string) []queryToken {
+	rs := []rune(query)
+	tokens := []queryToken{}
+	for i := 0; i < len(rs); {
+		if unicode.IsSpace(rs[i]) {
+			i++
+			continue
+		}
+
+		tok := queryToken{}
+		if rs[i] == '-' && i+1 < len(rs) && !unicode.IsSpace(rs[i+1]) {
+			tok.Negated = true
+			i++
+		}
+
+		j := i
+		for j < len(rs) && rs[j] != ':' && rs[j] != '"' && !unicode.IsSpace(rs[j]) {
+			j++
+		}
+		if j+1 < len(rs) && rs[j] == ':' && !unicode.IsSpace(rs[j+1]) {
+			field := strings.ToLower(string(rs[i:j]))
+			for _, f := range searchFields {
+				if f == field {
+					tok.Field = field
+					i = j + 1
+					break
+				}
+			}
+		}
+
+		if rs[i] == '"' {
+			end := i + 1
+			for end < len(rs) && rs[end] != '"' {
+				end++
+			}
+			tok.Value = strings.TrimSpace(string(rs[i+1 : min(end, len(rs))]))
+			tok.Phrase = true
+			i = end + 1
+		} else {
+			j = i
+			for j < len(rs) && !unicode.IsSpace(rs[j]) {
+				j++
+			}
+			tok.Value = strings.ReplaceAll(string(rs[i:j]), `"`, "")
+			i = j
+		}
+
+		if tok.Value == "" {
+			continue
+		}
+		if tok.Field == "" && !tok.Negated && !tok.Phrase && strings.EqualFold(tok.Value, "or") {
+			tok = queryToken{Or: true}
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens
+}
+
+// searchQuery is a parsed search query. Text, Title and Body are in websearch_to_tsquery
+// syntax and match the whole article, its title or its body.
+type searchQuery struct {
+	Text  string
+	Title string
+	Body  string
+	// Tags and Authors must all match; several authors match any of them
+	Tags            []string
+	ExcludedTags    []string
+	Authors         []string
+	ExcludedAuthors []string
+	// Highlight matches any wanted word or phrase, for ranking and <mark> highlighting
+	Highlight string
+	// Semantic is the wanted free text, embedded for semantic search, and Excluded the
+	// unwanted free-text terms that semantic search still excludes by keyword
+	Semantic string
+	Excluded string
+}
+
+// parseSearchQuery parses quoted phrases, -negation, OR and the title, body, tag and author
+// qualifiers. OR joins the terms on both sides when they have the same scope and is
+// otherwise ignored. Authors are matched by user ID; any other author: value, such as a
+// name typed into the search box, is searched as plain text.
+func parseSearchQuery(query string) searchQuery {
+	tokens := tokenizeSearchQuery(query)
+	for i, tok := range tokens {
+		if tok.Field == fieldAuthor {
+			if _, err := uuid.Parse(tok.Value); err != nil {
+				tokens[i].Field = ""
+			}
+		}
+	}
+	parts := map[string][]string{}
+	highlight := []string{}
+	semantic := []string{}
+	excluded := []string{}
+	q := searchQuery{}
+
+	for i, tok := range tokens {
+		switch {
+		case tok.Or:
+			if i > 0 && i+1 < len(tokens) && tokens[i-1].textual() && tokens[i+1].textual() &&
+				tokens[i-1].Field == tokens[i+1].Field {
+				parts[tokens[i-1].Field] = append(parts[tokens[i-1].Field], "OR")
+			}
+		case tok.Field == fieldTag:
+			value := strings.ToLower(tok.Value)
+			if tok.Negated {
+				q.ExcludedTags = append(q.ExcludedTags, value)
+			} else {
+				q.Tags = append(q.Tags, value)
+			}
+		case tok.Field == fieldAuthor:
+			if tok.Negated {
+				q.ExcludedAuthors = append(q.ExcludedAuthors, tok.Value)
+			} else {
+				q.Authors = append(q.Authors, tok.Value)
+			}
+		default:
+			parts[tok.Field] = append(parts[tok.Field], tok.text())
+			if !tok.Negated {
+				highlight = append(highlight, queryToken{Value: tok.Value, Phrase: tok.Phrase}.text())
+			}
+			if tok.Field == "" {
+				if tok.Negated {
+					excluded = append(excluded, tok.text())
+				} else {
+					semantic = append(semantic, tok.Value)
+				}
+			}
+		}
+	}
+
+	q.Text = strings.Join(parts[""], " ")
+	q.Title = strings.Join(parts[fieldTitle], " ")
+	q.Body = strings.Join(parts[fieldBody], " ")
+	q.Highlight = strings.Join(highlight, " OR ")
+	q.Semantic = strings.Join(semantic, " ")
+	q.Excluded = strings.Join(excluded, " ")
+	return q
+}
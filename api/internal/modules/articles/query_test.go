@@ -0,0 +1,62 @@
+package articles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenizeSearchQuery(t *testing.T) {
+	tokens := tokenizeSearchQuery(`"prompt engineering" -openai title:agents OR -tag:"ml ops" note:x`)
+	assert.Equal(t, []queryToken{
+		{Value: "prompt engineering", Phrase: true},
+		{Value: "openai", Negated: true},
+		{Field: "title", Value: "agents"},
+		{Or: true},
+		{Field: "tag", Value: "ml ops", Phrase: true, Negated: true},
+		{Value: "note:x"},
+	}, tokens)
+
+	// Empty phrases are dropped and a lone minus is a plain word
+	assert.Equal(t, []queryToken{{Value: "-"}}, tokenizeSearchQuery(` "" - `))
+	assert.Equal(t, []queryToken{{Value: "open quote", Phrase: true}}, tokenizeSearchQuery(`"open quote`))
+}
+
+func TestQueryTokenString(t *testing.T) {
+	for _, q := range []string{`"prompt engineering"`, `-openai`, `title:agents`, `-tag:"ml ops"`, `OR`} {
+		tokens := tokenizeSearchQuery(q)
+		if assert.Len(t, tokens, 1) {
+			assert.Equal(t, q, tokens[0].String())
+		}
+	}
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	q := parseSearchQuery(`"prompt engineering" -openai title:agents TAG:LLM -tag:crypto author:123e4567-e89b-12d3-a456-426614174000`)
+	assert.Equal(t, `"prompt engineering" -openai`, q.Text)
+	assert.Equal(t, "agents", q.Title)
+	assert.Empty(t, q.Body)
+	assert.Equal(t, []string{"llm"}, q.Tags)
+	assert.Equal(t, []string{"crypto"}, q.ExcludedTags)
+	assert.Equal(t, []string{"123e4567-e89b-12d3-a456-426614174000"}, q.Authors)
+	assert.Equal(t, `"prompt engineering" OR agents`, q.Highlight)
+
+	// OR joins terms of the same scope only
+	q = parseSearchQuery(`rust OR go body:wasm OR title:wasi`)
+	assert.Equal(t, "rust OR go", q.Text)
+	assert.Equal(t, "wasm", q.Body)
+	assert.Equal(t, "wasi", q.Title)
+
+	q = parseSearchQuery(`OR tag:go`)
+	assert.Empty(t, q.Text)
+	assert.Empty(t, q.Highlight)
+	assert.Equal(t, []string{"go"}, q.Tags)
+}
+
+func TestParseSearchQuery_AuthorName(t *testing.T) {
+	q := parseSearchQuery(`agents author:ivanov -author:"Jane Doe"`)
+	assert.Empty(t, q.Authors)
+	assert.Empty(t, q.ExcludedAuthors)
+	assert.Equal(t, `agents ivanov -"Jane Doe"`, q.Text)
+	assert.Equal(t, "agents ivanov", q.Semantic)
+}
@@ -0,0 +1,185 @@
+package articles
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxRelatedLimit bounds the number of related articles per request
+const maxRelatedLimit = 20
+
+// maxRelatedCacheEntries bounds the memory used by cached recommendations
+const maxRelatedCacheEntries = 1000
+
+// Weights of the related reading score. Text similarity is the ts_rank of the other
+// article against the most frequent lexemes of this one.
+const (
+	relatedTagWeight            = 3.0
+	relatedCategoryWeight       = 2.0
+	relatedParentCategoryWeight = 1.0
+	relatedTextWeight           = 10.0
+	// relatedTerms is the number of lexemes of the article used for text similarity
+	relatedTerms = 20
+)
+
+// RelatedArticle is a published article recommended next to another one
+type RelatedArticle struct {
+	Article
+	Score float64  `db:"score" json:"score"`
+	Tags  []string `json:"tags"`
+}
+
+// ListRelated scores other published articles by shared tags, same category, a parent,
+// child or sibling category, and text similarity, and returns the best matches
+func (r *Repository) ListRelated(articleID string, limit int) ([]RelatedArticle, error) {
+	query := `
+		WITH source AS (
+			SELECT a.id, a.search_vector, c.id AS category_id, c.parent_id AS category_parent_id
+			FROM articles a
+			LEFT JOIN categories c ON c.id = a.category_id
+			WHERE a.id = $1 AND a.deleted_at IS NULL
+		), terms AS (
+			SELECT string_agg(quote_literal(lexeme), ' | ')::tsquery AS query
+			FROM (
+				SELECT t.lexeme
+				FROM source s, unnest(s.search_vector) t
+				ORDER BY COALESCE(array_length(t.positions, 1), 0) DESC, t.lexeme
+				LIMIT $3
+			) top
+		), shared_tags AS (
+			SELECT at.article_id, COUNT(*) AS shared
+			FROM article_tags at
+			WHERE at.tag_id IN (SELECT tag_id FROM article_tags WHERE article_id = $1)
+			GROUP BY at.article_id
+		)
+		SELECT * FROM (
+			SELECT a.id, a.title, a.slug, a.body, a.category_id, a.author_id, a.status, a.language, a.published_at,
+				a.created_at, a.updated_at, a.likes_count, a.dislikes_count, a.comments_count,
+				COALESCE(st.shared, 0) * $4::float8
+				+ CASE
+					WHEN a.category_id = s.category_id THEN $5::float8
+					WHEN a.category_id = s.category_parent_id OR c.parent_id = s.category_id OR c.parent_id = s.category_parent_id THEN $6::float8
+					ELSE 0
+				END
+				+ COALESCE(ts_rank(a.search_vector, t.query), 0) * $7::float8 AS score
+			FROM articles a
+			CROSS JOIN source s
+			CROSS JOIN terms t
+			LEFT JOIN categories c ON c.id = a.category_id
+			LEFT JOIN shared_tags st ON st.article_id = a.id
+			WHERE a.id <> $1 AND a.status = 'PUBLISHED' AND a.deleted_at IS NULL
+		) ranked
+		WHERE score > 0
+		ORDER BY score DESC, published_at DESC
+		LIMIT $2
+	`
+	related := []RelatedArticle{}
+	err := r.db.Select(&related, query,
+		articleID,
+		limit,
+		relatedTerms,
+		relatedTagWeight,
+		relatedCategoryWeight,
+		relatedParentCategoryWeight,
+		relatedTextWeight,
+	)
+	return related, err
+}
+
+// relatedCache keeps recommendations for a while; they change only as articles are published
+type relatedCache struct {
+	mu      sync.Mutex
+	entries map[string]relatedCacheEntry
+}
+
+type relatedCacheEntry struct {
+	articles []RelatedArticle
+	expires  time.Time
+}
+
+func (c *relatedCache) get(key string, now time.Time) ([]RelatedArticle, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || now.After(entry.expires) {
+		return nil, false
+	}
+	return entry.articles, true
+}
+
+func (c *relatedCache) set(key string, articles []RelatedArticle, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = map[string]relatedCacheEntry{}
+	}
+	if len(c.entries) >= maxRelatedCacheEntries {
+		now := time.Now()
+		for k, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxRelatedCacheEntries {
+			c.entries = map[string]relatedCacheEntry{}
+		}
+	}
+	c.entries[key] = relatedCacheEntry{articles: articles, expires: expires}
+}
+
+// clampRelatedLimit applies the configured default and the upper bound to a requested limit
+func clampRelatedLimit(limit, def int) int {
+	if limit <= 0 {
+		limit = def
+	}
+	if limit <= 0 {
+		limit = 5
+	}
+	if limit > maxRelatedLimit {
+		limit = maxRelatedLimit
+	}
+	return limit
+}
+
+// Related recommends published articles to read after a published article, with previews and tags
+func (s *Service) Related(articleID string, limit int) ([]RelatedArticle, error) {
+	if _, err := uuid.Parse(articleID); err != nil {
+		return nil, ErrArticleNotFound
+	}
+	limit = clampRelatedLimit(limit, s.config.Related.Limit)
+
+	key := fmt.Sprintf("%s/%d", articleID, limit)
+	now := time.Now()
+	if cached, ok := s.related.get(key, now); ok {
+		return cached, nil
+	}
+
+	article, err := s.repo.FindByID(articleID)
+	if err != nil {
+		return nil, err
+	}
+	if article == nil || article.Status != StatusPublished {
+		return nil, ErrArticleNotFound
+	}
+
+	related, err := s.repo.ListRelated(articleID, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range related {
+		related[i].Body = generatePreview(related[i].Body)
+		tags, err := s.repo.GetTags(related[i].ID)
+		if err != nil {
+			tags = []string{}
+		}
+		related[i].Tags = tags
+	}
+
+	if s.config.Related.CacheTTL > 0 {
+		s.related.set(key, related, now.Add(s.config.Related.CacheTTL))
+	}
+	return related, nil
+}
@@ -0,0 +1,31 @@
+package articles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClampRelatedLimit(t *testing.T) {
+	assert.Equal(t, 5, clampRelatedLimit(0, 5))
+	assert.Equal(t, 5, clampRelatedLimit(-1, 0))
+	assert.Equal(t, 3, clampRelatedLimit(3, 5))
+	assert.Equal(t, maxRelatedLimit, clampRelatedLimit(500, 5))
+}
+
+func TestRelatedCache(t *testing.T) {
+	cache := &relatedCache{}
+	now := time.Now()
+
+	_, ok := cache.get("a/5", now)
+	assert.False(t, ok)
+
+	cache.set("a/5", []RelatedArticle{{Score: 1}}, now.Add(time.Minute))
+	cached, ok := cache.get("a/5", now)
+	assert.True(t, ok)
+	assert.Len(t, cached, 1)
+
+	_, ok = cache.get("a/5", now.Add(2*time.Minute))
+	assert.False(t, ok)
+}
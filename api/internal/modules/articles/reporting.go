@@ -0,0 +1,112 @@
+package articles
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// ArticleReports lets the reports module check, hide, restore and delete reported articles.
+// Hiding archives a published article; restoring publishes it again, but only when the
+// report threshold archived it rather than an editor or the scheduler.
+type ArticleReports struct {
+	repo *Repository
+}
+
+// NewArticleReports exposes the articles of the service as report targets
+func NewArticleReports(s *Service) ArticleReports {
+	return ArticleReports{repo: s.repo}
+}
+
+// Exists reports whether the article is published and can be reported
+func (a ArticleReports) Exists(id string) (bool, error) {
+	article, err := a.repo.FindByID(id)
+	if err != nil {
+		return false, err
+	}
+	return article != nil && article.Status == StatusPublished, nil
+}
+
+// Hide archives the article; an automatic hide is marked as caused by reports
+func (a ArticleReports) Hide(id, actorID, reason string) error {
+	if actorID == "" {
+		return ignoreNoRows(a.repo.HideForReports(id, reason))
+	}
+	return a.move(id, StatusPublished, StatusArchived, actorID, reason)
+}
+
+// Restore publishes the article again if reports archived it
+func (a ArticleReports) Restore(id, actorID string) error {
+	return ignoreNoRows(a.repo.RestoreFromReports(id, actorID, "reports dismissed"))
+}
+
+func (a ArticleReports) Delete(id, actorID string) error {
+	err := a.repo.Delete(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	return err
+}
+
+// move records a status change; an article in another status is left alone
+func (a ArticleReports) move(id, from, to, actorID, note string) error {
+	_, err := a.repo.TransitionStatus(id, from, to, actorID, &note)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	return err
+}
+
+// CommentReports lets the reports module check, hide, restore and delete reported comments.
+// Hiding rejects an approved comment; restoring approves it again, but only when the report
+// threshold rejected it rather than a moderator or the comment filters.
+type CommentReports struct {
+	repo *Repository
+}
+
+// NewCommentReports exposes the comments of the service as report targets
+func NewCommentReports(s *Service) CommentReports {
+	return CommentReports{repo: s.repo}
+}
+
+// Exists reports whether the comment is visible to readers and can be reported
+func (c CommentReports) Exists(id string) (bool, error) {
+	comment, err := c.repo.FindCommentByID(id)
+	if err != nil {
+		return false, err
+	}
+	return comment != nil && comment.DeletedAt == nil && comment.Status == CommentApproved, nil
+}
+
+// Hide rejects the comment; an automatic hide is marked as caused by reports
+func (c CommentReports) Hide(id, actorID, reason string) error {
+	if actorID == "" {
+		return ignoreNoRows(c.repo.HideCommentForReports(id, reason))
+	}
+	return c.move(id, CommentApproved, CommentRejected, actorID, &reason)
+}
+
+// Restore approves the comment again if reports rejected it
+func (c CommentReports) Restore(id, actorID string) error {
+	return ignoreNoRows(c.repo.RestoreCommentFromReports(id, actorID))
+}
+
+func (c CommentReports) Delete(id, actorID string) error {
+	err := c.repo.DeleteComment(id, actorID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	return err
+}
+
+// move changes the moderation status if the comment is still in the expected one
+func (c CommentReports) move(id, from, to, actorID string, reason *string) error {
+	return ignoreNoRows(c.repo.MoveCommentStatus(id, from, to, actorID, reason))
+}
+
+// ignoreNoRows treats a target no longer in the expected state as already handled
+func ignoreNoRows(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	return err
+}
@@ -1,9 +1,9 @@
 package articles
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"regexp"
 	"strings"
 	"time"
 
@@ -19,10 +19,18 @@ type Article struct {
 	CategoryID  *string    `db:"category_id" json:"category_id"`
 	AuthorID    string     `db:"author_id" json:"author_id"`
 	Status      string     `db:"status" json:"status"`
+	Language    string     `db:"language" json:"language"`
 	PublishedAt *time.Time `db:"published_at" json:"published_at,omitempty"`
+	PublishAt   *time.Time `db:"publish_at" json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `db:"unpublish_at" json:"unpublish_at,omitempty"`
 	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
 	DeletedAt   *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+
+	// Interaction counters, maintained by triggers on article_likes and comments
+	LikesCount    int `db:"likes_count" json:"likes_count"`
+	DislikesCount int `db:"dislikes_count" json:"dislikes_count"`
+	CommentsCount int `db:"comments_count" json:"comments_count"`
 }
 
 type FilterOptions struct {
@@ -43,8 +51,9 @@ type CategoryWithCount struct {
 }
 
 type TagWithCount struct {
-	Name         string `db:"name" json:"name"`
-	ArticleCount int    `db:"article_count" json:"article_count"`
+	Name         string  `db:"name" json:"name"`
+	ArticleCount int     `db:"article_count" json:"article_count"`
+	Score        float64 `db:"score" json:"score"`
 }
 
 type Repository struct {
@@ -57,12 +66,34 @@ func NewRepository(db *sqlx.DB) *Repository {
 
 // Create inserts a new article
 func (r *Repository) Create(article *Article) error {
+	return r.createArticle(r.db, article)
+}
+
+// CreateWithRevision inserts a new article and its first revision in one transaction
+func (r *Repository) CreateWithRevision(article *Article, rev *Revision) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := r.createArticle(tx, article); err != nil {
+		return err
+	}
+	rev.ArticleID = article.ID
+	if err := insertRevision(tx, rev); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *Repository) createArticle(q sqlx.Queryer, article *Article) error {
 	query := `
-		INSERT INTO articles (title, slug, body, category_id, author_id, status, published_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, created_at, updated_at
+		INSERT INTO articles (title, slug, body, category_id, author_id, status, published_at, language)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE(NULLIF($8, ''), 'en'))
+		RETURNING id, language, created_at, updated_at
 	`
-	return r.db.QueryRow(
+	return q.QueryRowx(
 		query,
 		article.Title,
 		article.Slug,
@@ -71,14 +102,16 @@ func (r *Repository) Create(article *Article) error {
 		article.AuthorID,
 		article.Status,
 		article.PublishedAt,
-	).Scan(&article.ID, &article.CreatedAt, &article.UpdatedAt)
+		article.Language,
+	).Scan(&article.ID, &article.Language, &article.CreatedAt, &article.UpdatedAt)
 }
 
 // FindByID retrieves an article by ID (excluding soft-deleted)
 func (r *Repository) FindByID(id string) (*Article, error) {
 	var article Article
 	query := `
-		SELECT id, title, slug, body, category_id, author_id, status, published_at, created_at, updated_at, deleted_at
+		SELECT id, title, slug, body, category_id, author_id, status, language, published_at, publish_at, unpublish_at, created_at, updated_at, deleted_at,
+			likes_count, dislikes_count, comments_count
 		FROM articles
 		WHERE id = $1 AND deleted_at IS NULL
 	`
@@ -93,7 +126,8 @@ func (r *Repository) FindByID(id string) (*Article, error) {
 func (r *Repository) FindBySlug(slug string) (*Article, error) {
 	var article Article
 	query := `
-		SELECT id, title, slug, body, category_id, author_id, status, published_at, created_at, updated_at, deleted_at
+		SELECT id, title, slug, body, category_id, author_id, status, language, published_at, publish_at, unpublish_at, created_at, updated_at, deleted_at,
+			likes_count, dislikes_count, comments_count
 		FROM articles
 		WHERE slug = $1 AND deleted_at IS NULL
 	`
@@ -107,7 +141,8 @@ func (r *Repository) FindBySlug(slug string) (*Article, error) {
 // FindAll retrieves all active articles with optional filters
 func (r *Repository) FindAll(opts FilterOptions) ([]Article, error) {
 	query := `
-		SELECT DISTINCT a.id, a.title, a.slug, a.body, a.category_id, a.author_id, a.status, a.published_at, a.created_at, a.updated_at
+		SELECT DISTINCT a.id, a.title, a.slug, a.body, a.category_id, a.author_id, a.status, a.language, a.published_at, a.publish_at, a.unpublish_at, a.created_at, a.updated_at,
+			a.likes_count, a.dislikes_count, a.comments_count
 		FROM articles a
 	`
 
@@ -120,7 +155,9 @@ func (r *Repository) FindAll(opts FilterOptions) ([]Article, error) {
 	args := []interface{}{}
 	argPos := 1
 
-	if opts.Status != "" {
+	if opts.Status == StatusScheduled {
+		query += ` AND ` + scheduledCondition
+	} else if opts.Status != "" {
 		query += fmt.Sprintf(` AND a.status = $%d`, argPos)
 		args = append(args, opts.Status)
 		argPos++
@@ -145,6 +182,9 @@ func (r *Repository) FindAll(opts FilterOptions) ([]Article, error) {
 	if opts.SortBy == "published_at" {
 		orderBy = "a.published_at DESC"
 	}
+	if opts.Status == StatusScheduled {
+		orderBy = "a.publish_at ASC NULLS LAST, a.unpublish_at ASC"
+	}
 
 	query += fmt.Sprintf(` ORDER BY %s LIMIT $%d OFFSET $%d`, orderBy, argPos, argPos+1)
 	args = append(args, opts.Limit, opts.Offset)
@@ -154,24 +194,236 @@ func (r *Repository) FindAll(opts FilterOptions) ([]Article, error) {
 	return articles, err
 }
 
-// Update modifies an existing article
+// StatusScheduled is a pseudo-status for listing articles with a pending publish or unpublish time
+const StatusScheduled = "SCHEDULED"
+
+const scheduledCondition = `((a.status = 'APPROVED' AND a.publish_at IS NOT NULL) OR (a.status = 'PUBLISHED' AND a.unpublish_at IS NOT NULL))`
+
+// Update modifies the content of an existing article. Status and publish date only
+// change through transitions, so they are left as stored.
 func (r *Repository) Update(id string, article *Article) error {
+	return r.updateArticle(r.db, id, article)
+}
+
+// UpdateWithRevision updates the content of an article and records the revision of that
+// save in one transaction, holding the article row lock so concurrent saves number
+// revisions in turn. check is called with the article as locked, so a save cannot be
+// allowed by a status a concurrent transition has already left.
+func (r *Repository) UpdateWithRevision(id string, article *Article, rev *Revision, check func(*Article) error) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, err := findLocked(tx, id)
+	if err != nil {
+		return err
+	}
+	if err := check(current); err != nil {
+		return err
+	}
+	if err := r.updateArticle(tx, id, article); err != nil {
+		return err
+	}
+	rev.ArticleID = id
+	if err := insertRevision(tx, rev); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *Repository) updateArticle(q sqlx.Queryer, id string, article *Article) error {
 	query := `
 		UPDATE articles
-		SET title = $1, slug = $2, body = $3, category_id = $4, status = $5, published_at = $6, updated_at = NOW()
-		WHERE id = $7 AND deleted_at IS NULL
-		RETURNING updated_at
+		SET title = $1, slug = $2, body = $3, category_id = $4,
+			language = COALESCE(NULLIF($6, ''), language), updated_at = NOW()
+		WHERE id = $5 AND deleted_at IS NULL
+		RETURNING status, published_at, language, updated_at
 	`
-	return r.db.QueryRow(
+	return q.QueryRowx(
 		query,
 		article.Title,
 		article.Slug,
 		article.Body,
 		article.CategoryID,
-		article.Status,
-		article.PublishedAt,
 		id,
-	).Scan(&article.UpdatedAt)
+		article.Language,
+	).Scan(&article.Status, &article.PublishedAt, &article.Language, &article.UpdatedAt)
+}
+
+// findLocked retrieves an article and locks its row until the end of the transaction
+func findLocked(tx *sqlx.Tx, id string) (*Article, error) {
+	var article Article
+	query := `
+		SELECT id, title, slug, body, category_id, author_id, status, language, published_at, publish_at, unpublish_at, created_at, updated_at, deleted_at,
+			likes_count, dislikes_count, comments_count
+		FROM articles
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`
+	if err := tx.Get(&article, query, id); err != nil {
+		return nil, err
+	}
+	return &article, nil
+}
+
+// lockArticle locks an article row until the end of the transaction
+func lockArticle(tx *sqlx.Tx, id string) error {
+	var locked string
+	return tx.Get(&locked, `SELECT id FROM articles WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id)
+}
+
+// SetSchedule sets or clears the scheduled publish and unpublish times
+func (r *Repository) SetSchedule(id string, publishAt, unpublishAt *time.Time) error {
+	query := `
+		UPDATE articles
+		SET publish_at = $1, unpublish_at = $2, updated_at = NOW()
+		WHERE id = $3 AND deleted_at IS NULL
+	`
+	result, err := r.db.Exec(query, publishAt, unpublishAt, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// PublishDue publishes approved articles whose publish time has passed.
+// Rows are locked with SKIP LOCKED so concurrent API replicas never process the same article.
+func (r *Repository) PublishDue(ctx context.Context, now time.Time) ([]string, error) {
+	query := `
+		WITH due AS (
+			UPDATE articles
+			SET status = 'PUBLISHED', published_at = publish_at, publish_at = NULL, updated_at = NOW()
+			WHERE id IN (
+				SELECT id FROM articles
+				WHERE status = 'APPROVED' AND publish_at <= $1 AND deleted_at IS NULL
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id
+		), logged AS (
+			INSERT INTO article_status_transitions (article_id, from_status, to_status, note)
+			SELECT id, 'APPROVED', 'PUBLISHED', 'scheduled' FROM due
+		)
+		SELECT id FROM due
+	`
+	ids := []string{}
+	err := r.db.SelectContext(ctx, &ids, query, now)
+	return ids, err
+}
+
+// ArchiveDue archives published articles whose unpublish time has passed
+func (r *Repository) ArchiveDue(ctx context.Context, now time.Time) ([]string, error) {
+	query := `
+		WITH due AS (
+			UPDATE articles
+			SET status = 'ARCHIVED', unpublish_at = NULL, updated_at = NOW()
+			WHERE id IN (
+				SELECT id FROM articles
+				WHERE status = 'PUBLISHED' AND unpublish_at <= $1 AND deleted_at IS NULL
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id
+		), logged AS (
+			INSERT INTO article_status_transitions (article_id, from_status, to_status, note)
+			SELECT id, 'PUBLISHED', 'ARCHIVED', 'scheduled' FROM due
+		)
+		SELECT id FROM due
+	`
+	ids := []string{}
+	err := r.db.SelectContext(ctx, &ids, query, now)
+	return ids, err
+}
+
+// TransitionStatus moves an article from one status to another and records the transition.
+// An empty actorID records a system transition. Republishing keeps the original publish date,
+// and any status change clears the mark left by HideForReports.
+// It returns sql.ErrNoRows if the article is no longer in the expected status.
+func (r *Repository) TransitionStatus(id, from, to, actorID string, note *string) (*StatusTransition, error) {
+	return r.transitionStatus(id, from, to, actorID, note, false, false)
+}
+
+// HideForReports archives a published article on behalf of reader reports and marks it
+// so dismissing the reports publishes it again. It returns sql.ErrNoRows if the article
+// is not published.
+func (r *Repository) HideForReports(id, note string) error {
+	_, err := r.transitionStatus(id, StatusPublished, StatusArchived, "", &note, true, false)
+	return err
+}
+
+// RestoreFromReports publishes again an article that reader reports archived. It returns
+// sql.ErrNoRows if the article is not archived or was archived for another reason.
+func (r *Repository) RestoreFromReports(id, actorID, note string) error {
+	_, err := r.transitionStatus(id, StatusArchived, StatusPublished, actorID, &note, false, true)
+	return err
+}
+
+// transitionStatus moves an article and logs the transition. hiddenByReports is stored on
+// the article; onlyHiddenByReports limits the move to an article that reports hid.
+func (r *Repository) transitionStatus(id, from, to, actorID string, note *string, hiddenByReports, onlyHiddenByReports bool) (*StatusTransition, error) {
+	query := `
+		WITH moved AS (
+			UPDATE articles
+			SET status = $3,
+				published_at = CASE WHEN $3 = 'PUBLISHED' THEN COALESCE(published_at, NOW()) ELSE published_at END,
+				hidden_by_reports = $6,
+				updated_at = NOW()
+			WHERE id = $1 AND status = $2 AND deleted_at IS NULL AND (NOT $7 OR hidden_by_reports)
+			RETURNING id
+		)
+		INSERT INTO article_status_transitions (article_id, from_status, to_status, actor_id, note)
+		SELECT id, $2, $3, NULLIF($4, '')::uuid, $5 FROM moved
+		RETURNING id, article_id, from_status, to_status, actor_id, note, created_at
+	`
+	var t StatusTransition
+	err := r.db.Get(&t, query, id, from, to, actorID, note, hiddenByReports, onlyHiddenByReports)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ListTransitions retrieves the status history of an article, oldest first
+func (r *Repository) ListTransitions(articleID string) ([]StatusTransition, error) {
+	query := `
+		SELECT id, article_id, from_status, to_status, actor_id, note, created_at
+		FROM article_status_transitions
+		WHERE article_id = $1
+		ORDER BY created_at ASC
+	`
+	history := []StatusTransition{}
+	err := r.db.Select(&history, query, articleID)
+	return history, err
+}
+
+// AddReviewNote stores a private reviewer note
+func (r *Repository) AddReviewNote(note *ReviewNote) error {
+	query := `
+		INSERT INTO article_review_notes (article_id, author_id, body)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+	return r.db.QueryRow(query, note.ArticleID, note.AuthorID, note.Body).Scan(&note.ID, &note.CreatedAt)
+}
+
+// ListReviewNotes retrieves the reviewer notes of an article, oldest first
+func (r *Repository) ListReviewNotes(articleID string) ([]ReviewNote, error) {
+	query := `
+		SELECT id, article_id, author_id, body, created_at
+		FROM article_review_notes
+		WHERE article_id = $1
+		ORDER BY created_at ASC
+	`
+	notes := []ReviewNote{}
+	err := r.db.Select(&notes, query, articleID)
+	return notes, err
 }
 
 // Delete performs soft delete
@@ -231,14 +483,109 @@ func (r *Repository) GetTags(articleID string) ([]string, error) {
 	return tags, err
 }
 
+// Revision is a snapshot of an article's content saved on every create and update
+type Revision struct {
+	ID             string    `db:"id" json:"id"`
+	ArticleID      string    `db:"article_id" json:"article_id"`
+	RevisionNumber int       `db:"revision_number" json:"revision_number"`
+	Title          string    `db:"title" json:"title"`
+	Body           string    `db:"body" json:"body,omitempty"`
+	CategoryID     *string   `db:"category_id" json:"category_id"`
+	EditorID       string    `db:"editor_id" json:"editor_id"`
+	CreatedAt      time.Time `db:"created_at" json:"created_at"`
+}
+
+// AddRevision stores a new revision with the next revision number for the article
+func (r *Repository) AddRevision(rev *Revision) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockArticle(tx, rev.ArticleID); err != nil {
+		return err
+	}
+	if err := insertRevision(tx, rev); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertRevision numbers a revision after the latest one; the caller holds the article lock
+func insertRevision(tx *sqlx.Tx, rev *Revision) error {
+	query := `
+		INSERT INTO article_revisions (article_id, revision_number, title, body, category_id, editor_id)
+		SELECT $1, COALESCE(MAX(revision_number), 0) + 1, $2, $3, $4, $5
+		FROM article_revisions
+		WHERE article_id = $1
+		RETURNING id, revision_number, created_at
+	`
+	return tx.QueryRowx(
+		query,
+		rev.ArticleID,
+		rev.Title,
+		rev.Body,
+		rev.CategoryID,
+		rev.EditorID,
+	).Scan(&rev.ID, &rev.RevisionNumber, &rev.CreatedAt)
+}
+
+// ListRevisions retrieves revision metadata for an article, newest first
+func (r *Repository) ListRevisions(articleID string) ([]Revision, error) {
+	query := `
+		SELECT id, article_id, revision_number, title, category_id, editor_id, created_at
+		FROM article_revisions
+		WHERE article_id = $1
+		ORDER BY revision_number DESC
+	`
+	revisions := []Revision{}
+	err := r.db.Select(&revisions, query, articleID)
+	return revisions, err
+}
+
+// GetRevision retrieves a single revision by its number
+func (r *Repository) GetRevision(articleID string, number int) (*Revision, error) {
+	var rev Revision
+	query := `
+		SELECT id, article_id, revision_number, title, body, category_id, editor_id, created_at
+		FROM article_revisions
+		WHERE article_id = $1 AND revision_number = $2
+	`
+	err := r.db.Get(&rev, query, articleID, number)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &rev, err
+}
+
 // Comment represents a user comment on an article
 type Comment struct {
+	ID         string     `json:"id" db:"id"`
+	ArticleID  string     `json:"article_id" db:"article_id"`
+	ParentID   *string    `json:"parent_id" db:"parent_id"`
+	Depth      int        `json:"depth" db:"depth"`
+	UserID     string     `json:"user_id" db:"user_id"`
+	Body       string     `json:"body" db:"body"`
+	Status     string     `json:"status" db:"status"`
+	ReplyCount int        `json:"reply_count" db:"reply_count"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+
+	// Moderation details, only shown to moderators
+	ModerationReason *string    `json:"moderation_reason,omitempty" db:"moderation_reason"`
+	ModeratedBy      *string    `json:"moderated_by,omitempty" db:"moderated_by"`
+	ModeratedAt      *time.Time `json:"moderated_at,omitempty" db:"moderated_at"`
+}
+
+// CommentEdit is a previous body of an edited comment
+type CommentEdit struct {
 	ID        string    `json:"id" db:"id"`
-	ArticleID string    `json:"article_id" db:"article_id"`
-	UserID    string    `json:"user_id" db:"user_id"`
+	CommentID string    `json:"comment_id" db:"comment_id"`
 	Body      string    `json:"body" db:"body"`
+	EditorID  string    `json:"editor_id" db:"editor_id"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
 
 // ArticleLike represents a user like/dislike on an article
@@ -249,32 +596,242 @@ type ArticleLike struct {
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
 
-// AddComment adds a new comment
+// AddComment adds a new comment or reply
 func (r *Repository) AddComment(comment *Comment) error {
 	query := `
-		INSERT INTO comments (article_id, user_id, body)
-		VALUES ($1, $2, $3)
+		INSERT INTO comments (article_id, parent_id, depth, user_id, body, status, moderation_reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING id, created_at, updated_at
 	`
 	return r.db.QueryRow(
 		query,
 		comment.ArticleID,
+		comment.ParentID,
+		comment.Depth,
 		comment.UserID,
 		comment.Body,
+		comment.Status,
+		comment.ModerationReason,
 	).Scan(&comment.ID, &comment.CreatedAt, &comment.UpdatedAt)
 }
 
-// GetComments retrieves comments for an article
-func (r *Repository) GetComments(articleID string) ([]Comment, error) {
+// commentColumns selects a comment together with the number of its approved direct replies
+const commentColumns = `
+	c.id, c.article_id, c.parent_id, c.depth, c.user_id, c.body, c.status, c.created_at, c.updated_at, c.deleted_at,
+	c.moderation_reason, c.moderated_by, c.moderated_at,
+	(SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.id AND r.status = 'APPROVED') AS reply_count`
+
+// ListTopLevelComments retrieves a page of an article's approved top-level comments, newest first.
+// limit+1 rows are fetched so the caller can tell whether another page exists.
+func (r *Repository) ListTopLevelComments(articleID string, after *CommentCursor, limit int) ([]Comment, error) {
+	query := `SELECT ` + commentColumns + ` FROM comments c
+		WHERE c.article_id = $1 AND c.parent_id IS NULL AND c.status = 'APPROVED'`
+	args := []interface{}{articleID}
+	if after != nil {
+		query += ` AND (c.created_at, c.id) < ($2, $3)`
+		args = append(args, after.CreatedAt, after.ID)
+	}
+	query += fmt.Sprintf(` ORDER BY c.created_at DESC, c.id DESC LIMIT $%d`, len(args)+1)
+	args = append(args, limit+1)
+
+	var comments []Comment
+	err := r.db.Select(&comments, query, args...)
+	return comments, err
+}
+
+// ListReplies retrieves a page of approved direct replies to a comment, oldest first.
+// limit+1 rows are fetched so the caller can tell whether another page exists.
+func (r *Repository) ListReplies(parentID string, after *CommentCursor, limit int) ([]Comment, error) {
+	query := `SELECT ` + commentColumns + ` FROM comments c WHERE c.parent_id = $1 AND c.status = 'APPROVED'`
+	args := []interface{}{parentID}
+	if after != nil {
+		query += ` AND (c.created_at, c.id) > ($2, $3)`
+		args = append(args, after.CreatedAt, after.ID)
+	}
+	query += fmt.Sprintf(` ORDER BY c.created_at ASC, c.id ASC LIMIT $%d`, len(args)+1)
+	args = append(args, limit+1)
+
+	var comments []Comment
+	err := r.db.Select(&comments, query, args...)
+	return comments, err
+}
+
+// FindCommentByID retrieves a single comment
+func (r *Repository) FindCommentByID(id string) (*Comment, error) {
+	var comment Comment
+	query := `SELECT ` + commentColumns + ` FROM comments c WHERE c.id = $1`
+	err := r.db.Get(&comment, query, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &comment, err
+}
+
+// UpdateComment replaces the body of a comment, keeping the previous body in comment_edits.
+// A non-nil verdict replaces the moderation status, e.g. when the new body fails the filters.
+// It returns sql.ErrNoRows if the comment does not exist or has been deleted.
+func (r *Repository) UpdateComment(id, body, editorID string, verdict *CommentVerdict) (time.Time, error) {
 	query := `
-		SELECT id, article_id, user_id, body, created_at, updated_at
-		FROM comments
-		WHERE article_id = $1
+		WITH previous AS (
+			SELECT id, body FROM comments
+			WHERE id = $1 AND deleted_at IS NULL
+			FOR UPDATE
+		), saved AS (
+			INSERT INTO comment_edits (comment_id, body, editor_id)
+			SELECT id, body, $3 FROM previous
+		)
+		UPDATE comments c
+		SET body = $2,
+			status = COALESCE($4, c.status),
+			moderation_reason = CASE WHEN $4::text IS NULL THEN c.moderation_reason ELSE $5 END,
+			hidden_by_reports = c.hidden_by_reports AND $4::text IS NULL,
+			updated_at = NOW()
+		FROM previous
+		WHERE c.id = previous.id
+		RETURNING c.updated_at
+	`
+	var status, reason *string
+	if verdict != nil {
+		status = &verdict.Status
+		if verdict.Reason != "" {
+			reason = &verdict.Reason
+		}
+	}
+	var updatedAt time.Time
+	err := r.db.QueryRow(query, id, body, editorID, status, reason).Scan(&updatedAt)
+	return updatedAt, err
+}
+
+// DeleteComment soft deletes a comment; its replies stay attached to it.
+// It returns sql.ErrNoRows if the comment does not exist or is already deleted.
+func (r *Repository) DeleteComment(id, actorID string) error {
+	query := `UPDATE comments SET deleted_at = NOW(), deleted_by = $2 WHERE id = $1 AND deleted_at IS NULL`
+	result, err := r.db.Exec(query, id, actorID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ListCommentEdits retrieves the previous bodies of a comment, oldest first
+func (r *Repository) ListCommentEdits(commentID string) ([]CommentEdit, error) {
+	query := `
+		SELECT id, comment_id, body, editor_id, created_at
+		FROM comment_edits
+		WHERE comment_id = $1
+		ORDER BY created_at ASC
+	`
+	edits := []CommentEdit{}
+	err := r.db.Select(&edits, query, commentID)
+	return edits, err
+}
+
+// CommenterHistory returns how many of a user's comments are approved and the bodies
+// of the user's comments created since the given time, except the comment being edited
+// (empty for a new comment)
+func (r *Repository) CommenterHistory(userID string, since time.Time, editedID string) (int, []string, error) {
+	var approved int
+	err := r.db.Get(&approved, `SELECT COUNT(*) FROM comments WHERE user_id = $1 AND status = 'APPROVED'`, userID)
+	if err != nil {
+		return 0, nil, err
+	}
+	bodies := []string{}
+	err = r.db.Select(&bodies, `
+		SELECT body FROM comments
+		WHERE user_id = $1 AND created_at >= $2 AND deleted_at IS NULL
+		  AND id IS DISTINCT FROM NULLIF($3, '')::uuid
 		ORDER BY created_at DESC
+		LIMIT 50
+	`, userID, since, editedID)
+	return approved, bodies, err
+}
+
+// ListCommentsByStatus retrieves comments in a moderation status across all articles, oldest first
+func (r *Repository) ListCommentsByStatus(status string, limit, offset int) ([]Comment, int, error) {
+	var total int
+	err := r.db.Get(&total, `SELECT COUNT(*) FROM comments WHERE status = $1 AND deleted_at IS NULL`, status)
+	if err != nil {
+		return nil, 0, err
+	}
+	query := `SELECT ` + commentColumns + ` FROM comments c
+		WHERE c.status = $1 AND c.deleted_at IS NULL
+		ORDER BY c.created_at ASC, c.id ASC
+		LIMIT $2 OFFSET $3`
+	comments := []Comment{}
+	err = r.db.Select(&comments, query, status, limit, offset)
+	return comments, total, err
+}
+
+// SetCommentStatus records a moderation decision on a comment; an empty moderatorID records
+// an automatic one. It returns sql.ErrNoRows if the comment does not exist or has been deleted.
+func (r *Repository) SetCommentStatus(id, status, moderatorID string, reason *string) error {
+	query := `
+		UPDATE comments
+		SET status = $2, moderation_reason = $4, moderated_by = NULLIF($3, '')::uuid, moderated_at = NOW(),
+			hidden_by_reports = FALSE
+		WHERE id = $1 AND deleted_at IS NULL
 	`
-	var comments []Comment
-	err := r.db.Select(&comments, query, articleID)
-	return comments, err
+	return execOne(r.db, query, id, status, moderatorID, reason)
+}
+
+// MoveCommentStatus records a moderation decision on a comment still in the from status.
+// It returns sql.ErrNoRows if the comment is deleted or in another status.
+func (r *Repository) MoveCommentStatus(id, from, to, moderatorID string, reason *string) error {
+	query := `
+		UPDATE comments
+		SET status = $3, moderation_reason = $5, moderated_by = NULLIF($4, '')::uuid, moderated_at = NOW(),
+			hidden_by_reports = FALSE
+		WHERE id = $1 AND status = $2 AND deleted_at IS NULL
+	`
+	return execOne(r.db, query, id, from, to, moderatorID, reason)
+}
+
+// HideCommentForReports rejects an approved comment on behalf of reader reports and marks
+// it so dismissing the reports approves it again. It returns sql.ErrNoRows if the comment
+// is not approved.
+func (r *Repository) HideCommentForReports(id, reason string) error {
+	query := `
+		UPDATE comments
+		SET status = 'REJECTED', moderation_reason = $2, moderated_by = NULL, moderated_at = NOW(),
+			hidden_by_reports = TRUE
+		WHERE id = $1 AND status = 'APPROVED' AND deleted_at IS NULL
+	`
+	return execOne(r.db, query, id, reason)
+}
+
+// RestoreCommentFromReports approves again a comment that reader reports rejected. It
+// returns sql.ErrNoRows if the comment is not rejected or was rejected for another reason.
+func (r *Repository) RestoreCommentFromReports(id, moderatorID string) error {
+	query := `
+		UPDATE comments
+		SET status = 'APPROVED', moderation_reason = NULL, moderated_by = NULLIF($2, '')::uuid, moderated_at = NOW(),
+			hidden_by_reports = FALSE
+		WHERE id = $1 AND status = 'REJECTED' AND hidden_by_reports AND deleted_at IS NULL
+	`
+	return execOne(r.db, query, id, moderatorID)
+}
+
+// execOne runs a statement and returns sql.ErrNoRows if it changed no row
+func execOne(db *sqlx.DB, query string, args ...interface{}) error {
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
 }
 
 // AddLike adds or updates a like/dislike
@@ -296,22 +853,18 @@ func (r *Repository) RemoveLike(articleID, userID string) error {
 	return err
 }
 
-// GetLikesCount retrieves the count of likes and dislikes for an article
+// GetLikesCount returns the like and dislike counters of an article
 func (r *Repository) GetLikesCount(articleID string) (int, int, error) {
-	var likes int
-	var dislikes int
-
-	queryLikes := `SELECT COUNT(*) FROM article_likes WHERE article_id = $1 AND is_like = TRUE`
-	if err := r.db.Get(&likes, queryLikes, articleID); err != nil {
-		return 0, 0, err
+	var counts struct {
+		Likes    int `db:"likes_count"`
+		Dislikes int `db:"dislikes_count"`
 	}
-
-	queryDislikes := `SELECT COUNT(*) FROM article_likes WHERE article_id = $1 AND is_like = FALSE`
-	if err := r.db.Get(&dislikes, queryDislikes, articleID); err != nil {
-		return 0, 0, err
+	query := `SELECT likes_count, dislikes_count FROM articles WHERE id = $1`
+	err := r.db.Get(&counts, query, articleID)
+	if err == sql.ErrNoRows {
+		return 0, 0, nil
 	}
-
-	return likes, dislikes, nil
+	return counts.Likes, counts.Dislikes, err
 }
 
 // GetUserLike checks if a user has liked/disliked an article
@@ -328,6 +881,24 @@ func (r *Repository) GetUserLike(articleID, userID string) (*bool, error) {
 	return &isLike, nil
 }
 
+// GetUserLikes returns a user's reactions to the given articles, keyed by article ID.
+// Articles the user has not reacted to are absent from the map.
+func (r *Repository) GetUserLikes(articleIDs []string, userID string) (map[string]bool, error) {
+	var rows []struct {
+		ArticleID string `db:"article_id"`
+		IsLike    bool   `db:"is_like"`
+	}
+	query := `SELECT article_id, is_like FROM article_likes WHERE user_id = $1 AND article_id = ANY($2)`
+	if err := r.db.Select(&rows, query, userID, pq.Array(articleIDs)); err != nil {
+		return nil, err
+	}
+	likes := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		likes[row.ArticleID] = row.IsLike
+	}
+	return likes, nil
+}
+
 // Count returns total number of articles matching filters
 func (r *Repository) Count(opts FilterOptions) (int, error) {
 	query := `SELECT COUNT(DISTINCT a.id) FROM articles a`
@@ -341,7 +912,9 @@ func (r *Repository) Count(opts FilterOptions) (int, error) {
 	args := []interface{}{}
 	argPos := 1
 
-	if opts.Status != "" {
+	if opts.Status == StatusScheduled {
+		query += ` AND ` + scheduledCondition
+	} else if opts.Status != "" {
 		query += fmt.Sprintf(` AND a.status = $%d`, argPos)
 		args = append(args, opts.Status)
 		argPos++
@@ -381,88 +954,317 @@ func (r *Repository) GetCategoriesWithCounts() ([]CategoryWithCount, error) {
 	return categories, err
 }
 
-// GetTagsWithCounts retrieves tags with their article counts
+// GetTagsWithCounts retrieves tags with their published article counts.
+// Popular tags are ranked by the trending score of their articles over the last 30 days,
+// so tags with recent activity come first; otherwise tags are ranked by article count.
 func (r *Repository) GetTagsWithCounts(popular bool, limit int) ([]TagWithCount, error) {
 	query := `
-		SELECT t.code as name, COUNT(DISTINCT a.id) as article_count
+		SELECT t.code as name, COUNT(DISTINCT a.id) as article_count, COALESCE(SUM(tr.score), 0) as score
 		FROM article_tags at
 		JOIN tags t ON at.tag_id = t.id
 		JOIN articles a ON at.article_id = a.id
-		WHERE a.status = 'published' AND a.deleted_at IS NULL
+		LEFT JOIN article_trending tr ON tr.article_id = a.id AND tr.period = $1
+		WHERE a.status = 'PUBLISHED' AND a.deleted_at IS NULL
 		GROUP BY t.code
 	`
 
 	if popular {
-		query += ` HAVING COUNT(DISTINCT a.id) >= 1` // Simplified for now, can be adjusted
+		query += ` ORDER BY score DESC, article_count DESC, t.code`
+	} else {
+		query += ` ORDER BY article_count DESC, t.code`
 	}
 
-	query += ` ORDER BY article_count DESC, t.code`
-
 	if limit > 0 {
 		query += fmt.Sprintf(` LIMIT %d`, limit)
 	}
 
-	var tags []TagWithCount
-	err := r.db.Select(&tags, query)
+	tags := []TagWithCount{}
+	err := r.db.Select(&tags, query, popularTagsWindow)
 	return tags, err
 }
 
-// SearchResult represents a search result with highlights
+// SearchResult represents a search result with highlights. Title and Excerpt are
+// HTML-escaped text with matches wrapped in <mark>.
 type SearchResult struct {
-	ID          string     `json:"id"`
-	Title       string     `json:"title"`
-	Excerpt     string     `json:"excerpt"`
-	Slug        string     `json:"slug"`
-	CategoryID  *string    `json:"category_id"`
-	PublishedAt *time.Time `json:"published_at"`
+	ID          string     `db:"id" json:"id"`
+	Title       string     `db:"title" json:"title"`
+	Excerpt     string     `db:"excerpt" json:"excerpt"`
+	Slug        string     `db:"slug" json:"slug"`
+	CategoryID  *string    `db:"category_id" json:"category_id"`
+	Language    string     `db:"language" json:"language"`
+	PublishedAt *time.Time `db:"published_at" json:"published_at"`
 	Tags        []string   `json:"tags"`
 }
 
-// Search performs full-text search on articles
-func (r *Repository) Search(query string, categoryID string, tags []string, limit, offset int) ([]SearchResult, int, error) {
-	// Build the main search query
-	searchQuery := `
-		SELECT a.id, a.title, a.slug, a.category_id, a.published_at,
-			   ts_headline('english', a.body, plainto_tsquery('english', $1), 'StartSel=<mark>, StopSel=</mark>') as excerpt
-		FROM articles a
-		WHERE a.search_vector @@ plainto_tsquery('english', $1)
-		  AND a.status = 'PUBLISHED'
-		  AND a.deleted_at IS NULL
-	`
+// SearchOptions narrows a full-text search. An empty Language searches every language.
+type SearchOptions struct {
+	Query string
+	// CategoryIDs matches articles in any of the categories
+	CategoryIDs []string
+	Tags        []string
+	// MatchAllTags requires every tag instead of any of them
+	MatchAllTags bool
+	Language     string
+	// PublishedFrom and PublishedTo bound the publication day, both inclusive; zero is unbounded
+	PublishedFrom time.Time
+	PublishedTo   time.Time
+	// Mode is SearchModeKeyword (the default), SearchModeSemantic or SearchModeHybrid
+	Mode string
+	// Embedding is the query vector of semantic and hybrid searches in the space of EmbeddingModel;
+	// articles need a chunk at least MinSimilarity close to it
+	Embedding      []float32
+	EmbeddingModel string
+	MinSimilarity  float64
+	Limit          int
+	Offset         int
+}
 
-	args := []interface{}{query}
-	argPos := 2
+// Search modes: keyword ranks by full-text relevance, semantic by the cosine similarity of
+// embeddings, and hybrid matches either way and blends both scores
+const (
+	SearchModeKeyword  = "keyword"
+	SearchModeSemantic = "semantic"
+	SearchModeHybrid   = "hybrid"
+)
+
+// Weights of the hybrid score. The text rank is normalized to [0, 1) like the similarity.
+const (
+	hybridTextWeight       = 0.4
+	hybridSimilarityWeight = 0.6
+)
+
+// semantic reports whether the search matches by embedding
+func (opts SearchOptions) semantic() bool {
+	return (opts.Mode == SearchModeSemantic || opts.Mode == SearchModeHybrid) && len(opts.Embedding) > 0
+}
+
+// SearchPage is one page of search results with the refinements available for the query
+type SearchPage struct {
+	Results []SearchResult
+	Total   int
+	Facets  SearchFacets
+	// Mode is how the results were matched and ranked
+	Mode string
+}
+
+// searchConfigs maps article languages to their text search configurations,
+// matching article_search_config in the database
+var searchConfigs = map[string]string{
+	"en": "english",
+	"ru": "russian",
+	"kk": "kazakh",
+}
+
+// Vectors a search matches against. Titles are indexed with weight A and bodies with weight B.
+const (
+	articleVector = `a.search_vector`
+	titleVector   = `ts_filter(a.search_vector, '{a}')`
+	bodyVector    = `ts_filter(a.search_vector, '{b}')`
+)
+
+// semanticSimilarity is the similarity of the closest chunk of an article to the query
+// vector in $1, among the embeddings of the model in $2
+const semanticSimilarity = `COALESCE((
+				SELECT MAX(cosine_similarity(e.embedding, $1::real[]))
+				FROM article_embeddings e
+				WHERE e.article_id = a.id AND e.model = $2
+			), 0)`
+
+// searchMatch builds the condition matching a vector against the websearch query in
+// parameter param, parsing it with the configuration of each article's language so
+// stemming is consistent
+func searchMatch(language, vector string, param int) string {
+	languages := Languages
+	if language != "" {
+		languages = []string{language}
+	}
+	conditions := make([]string, len(languages))
+	for i, lang := range languages {
+		conditions[i] = fmt.Sprintf(`(a.language = '%s' AND %s @@ websearch_to_tsquery('%s', $%d))`, lang, vector, searchConfigs[lang], param)
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")"
+}
+
+// allTagsMatch builds the condition requiring every tag code in parameter param
+func allTagsMatch(param int) string {
+	return fmt.Sprintf(`(
+				SELECT COUNT(DISTINCT t.code) FROM article_tags at2
+				JOIN tags t ON at2.tag_id = t.id
+				WHERE at2.article_id = a.id AND t.code = ANY($%d::text[])
+			) = cardinality(ARRAY(SELECT DISTINCT unnest($%d::text[])))`, param, param)
+}
+
+// anyTagMatches builds the condition requiring any tag code in parameter param
+func anyTagMatches(param int) string {
+	return fmt.Sprintf(`EXISTS (
+				SELECT 1 FROM article_tags at2
+				JOIN tags t ON at2.tag_id = t.id
+				WHERE at2.article_id = a.id AND t.code = ANY($%d::text[])
+			)`, param)
+}
+
+// searchFilter names a filter that a facet may leave out of its counts
+type searchFilter int
+
+const (
+	filterNone searchFilter = iota
+	filterCategories
+	filterTags
+	filterPublished
+)
+
+// searchWhere builds the WHERE clause of a search, leaving out one filter. It returns the
+// clause and its arguments: for semantic matching the query vector, its model and the
+// minimum similarity, then the words to match and the filters. Every argument is used by
+// the clause, so count and facet queries can bind them as they are.
+func searchWhere(opts SearchOptions, skip searchFilter) (string, []interface{}) {
+	q := parseSearchQuery(opts.Query)
+	where := ` WHERE a.status = 'PUBLISHED'
+		  AND a.deleted_at IS NULL`
+
+	args := []interface{}{}
+	argPos := 1
+
+	text := q.Text
+	if opts.semantic() {
+		args = append(args, pq.Array(opts.Embedding), opts.EmbeddingModel, opts.MinSimilarity)
+		argPos += 3
+		similar := semanticSimilarity + ` >= $3`
+
+		switch {
+		case opts.Mode == SearchModeSemantic:
+			// The meaning of the wanted words is matched by embedding; exclusions still apply
+			where += ` AND ` + similar
+			text = q.Excluded
+		case text != "":
+			// Either side may match the wanted words, but exclusions apply to both
+			where += ` AND (` + similar + ` OR ` + searchMatch(opts.Language, articleVector, argPos) + `)`
+			args = append(args, text)
+			argPos++
+			text = q.Excluded
+		default:
+			where += ` AND ` + similar
+		}
+	}
+
+	matched := false
+	for _, match := range []struct{ query, vector string }{
+		{text, articleVector},
+		{q.Title, titleVector},
+		{q.Body, bodyVector},
+	} {
+		if match.query == "" {
+			continue
+		}
+		where += ` AND ` + searchMatch(opts.Language, match.vector, argPos)
+		args = append(args, match.query)
+		argPos++
+		matched = true
+	}
+	if opts.Language != "" && !matched {
+		where += fmt.Sprintf(` AND a.language = $%d`, argPos)
+		args = append(args, opts.Language)
+		argPos++
+	}
+
+	if len(q.Tags) > 0 {
+		where += ` AND ` + allTagsMatch(argPos)
+		args = append(args, pq.Array(q.Tags))
+		argPos++
+	}
+	if len(q.ExcludedTags) > 0 {
+		where += ` AND NOT ` + anyTagMatches(argPos)
+		args = append(args, pq.Array(q.ExcludedTags))
+		argPos++
+	}
+	if len(q.Authors) > 0 {
+		where += fmt.Sprintf(` AND a.author_id = ANY($%d::uuid[])`, argPos)
+		args = append(args, pq.Array(q.Authors))
+		argPos++
+	}
+	if len(q.ExcludedAuthors) > 0 {
+		where += fmt.Sprintf(` AND a.author_id <> ALL($%d::uuid[])`, argPos)
+		args = append(args, pq.Array(q.ExcludedAuthors))
+		argPos++
+	}
 
-	if categoryID != "" {
-		searchQuery += fmt.Sprintf(` AND a.category_id = $%d`, argPos)
-		args = append(args, categoryID)
+	if len(opts.CategoryIDs) > 0 && skip != filterCategories {
+		where += fmt.Sprintf(` AND a.category_id = ANY($%d::uuid[])`, argPos)
+		args = append(args, pq.Array(opts.CategoryIDs))
 		argPos++
 	}
 
-	if len(tags) > 0 {
-		searchQuery += ` AND EXISTS (
-			SELECT 1 FROM article_tags at2
-			JOIN tags t ON at2.tag_id = t.id
-			WHERE at2.article_id = a.id AND t.code = ANY($` + fmt.Sprintf("%d", argPos) + `)
-		)`
-		args = append(args, pq.Array(tags))
+	if len(opts.Tags) > 0 && skip != filterTags {
+		if opts.MatchAllTags {
+			where += ` AND ` + allTagsMatch(argPos)
+		} else {
+			where += ` AND ` + anyTagMatches(argPos)
+		}
+		args = append(args, pq.Array(opts.Tags))
 		argPos++
 	}
 
-	searchQuery += fmt.Sprintf(` ORDER BY ts_rank(a.search_vector, plainto_tsquery('english', $1)) DESC LIMIT $%d OFFSET $%d`, argPos, argPos+1)
-	args = append(args, limit, offset)
+	if skip != filterPublished {
+		if !opts.PublishedFrom.IsZero() {
+			where += fmt.Sprintf(` AND a.published_at >= $%d`, argPos)
+			args = append(args, opts.PublishedFrom)
+			argPos++
+		}
+		if !opts.PublishedTo.IsZero() {
+			where += fmt.Sprintf(` AND a.published_at < $%d`, argPos)
+			args = append(args, opts.PublishedTo.AddDate(0, 0, 1))
+			argPos++
+		}
+	}
+
+	return where, args
+}
+
+// searchScore is the ranking expression of a search, using the arguments of searchWhere
+// and the words to rank in parameter highlight
+func searchScore(opts SearchOptions, highlight int) string {
+	rank := fmt.Sprintf(`ts_rank(a.search_vector, websearch_to_tsquery(article_search_config(a.language), $%d)`, highlight)
+	if !opts.semantic() {
+		return rank + `)`
+	}
+	if opts.Mode == SearchModeSemantic {
+		return semanticSimilarity
+	}
+	return fmt.Sprintf(`%g * %s, 32) + %g * %s`, hybridTextWeight, rank, hybridSimilarityWeight, semanticSimilarity)
+}
+
+// escapeHTML wraps a SQL text expression so its HTML special characters are escaped.
+// Titles and bodies are user-authored and the highlighted text is rendered as HTML, so
+// only the <mark> tags added by ts_headline may reach the client as markup.
+func escapeHTML(expr string) string {
+	return `replace(replace(replace(replace(replace(` + expr +
+		`, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+}
 
-	var results []SearchResult
-	err := r.db.Select(&results, searchQuery, args...)
+// Search performs full-text search on published articles and counts the refinements of the query
+func (r *Repository) Search(opts SearchOptions) (*SearchPage, error) {
+	where, args := searchWhere(opts, filterNone)
+	argPos := len(args) + 1
+	// The words to rank and highlight are bound after the page, only for the results query
+	highlight := argPos + 2
+
+	// Build the main search query; titles weigh more than bodies in the text rank
+	headline := fmt.Sprintf(`websearch_to_tsquery(article_search_config(a.language), $%d)`, highlight)
+	searchQuery := `
+		SELECT a.id, a.slug, a.category_id, a.language, a.published_at,
+			   ts_headline(article_search_config(a.language), ` + escapeHTML(`a.title`) + `, ` + headline + `, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') as title,
+			   ts_headline(article_search_config(a.language), ` + escapeHTML(`a.body`) + `, ` + headline + `, 'StartSel=<mark>, StopSel=</mark>') as excerpt
+		FROM articles a` + where +
+		fmt.Sprintf(` ORDER BY %s DESC, a.published_at DESC LIMIT $%d OFFSET $%d`, searchScore(opts, highlight), argPos, argPos+1)
+
+	results := []SearchResult{}
+	err := r.db.Select(&results, searchQuery, append(args, opts.Limit, opts.Offset, parseSearchQuery(opts.Query).Highlight)...)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	// Highlight titles and get tags for each result
+	// Get tags for each result
 	for i := range results {
-		// Highlight title
-		results[i].Title = highlightText(results[i].Title, query)
-
 		// Get tags
 		tags, err := r.GetTags(results[i].ID)
 		if err != nil {
@@ -472,55 +1274,20 @@ func (r *Repository) Search(query string, categoryID string, tags []string, limi
 	}
 
 	// Get total count
-	countQuery := `
-		SELECT COUNT(*)
-		FROM articles a
-		WHERE a.search_vector @@ plainto_tsquery('english', $1)
-		  AND a.status = 'PUBLISHED'
-		  AND a.deleted_at IS NULL
-	`
-
-	countArgs := []interface{}{query}
-	countArgPos := 2
-
-	if categoryID != "" {
-		countQuery += fmt.Sprintf(` AND a.category_id = $%d`, countArgPos)
-		countArgs = append(countArgs, categoryID)
-		countArgPos++
-	}
-
-	if len(tags) > 0 {
-		countQuery += ` AND EXISTS (
-			SELECT 1 FROM article_tags at2
-			JOIN tags t ON at2.tag_id = t.id
-			WHERE at2.article_id = a.id AND t.code = ANY($` + fmt.Sprintf("%d", countArgPos) + `)
-		)`
-		countArgs = append(countArgs, pq.Array(tags))
-	}
-
 	var total int
-	err = r.db.Get(&total, countQuery, countArgs...)
+	err = r.db.Get(&total, `SELECT COUNT(*) FROM articles a`+where, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	return results, total, nil
-}
-
-// highlightText highlights search terms in text using <mark> tags
-func highlightText(text, query string) string {
-	// Simple case-insensitive highlighting
-	// Split query into words
-	terms := strings.Fields(strings.ToLower(query))
+	facets, err := r.searchFacets(opts)
+	if err != nil {
+		return nil, err
+	}
 
-	result := text
-	for _, term := range terms {
-		if len(term) < 2 {
-			continue
-		}
-		// Use regex to replace case-insensitively
-		re := regexp.MustCompile(`(?i)(` + regexp.QuoteMeta(term) + `)`)
-		result = re.ReplaceAllString(result, `<mark>$1</mark>`)
+	mode := SearchModeKeyword
+	if opts.semantic() {
+		mode = opts.Mode
 	}
-	return result
+	return &SearchPage{Results: results, Total: total, Facets: *facets, Mode: mode}, nil
 }
@@ -1,12 +1,16 @@
 package articles
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/ai-dala/api/internal/testutil"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -117,11 +121,35 @@ func TestRepository_Integration(t *testing.T) {
 		err := repo.Update(article.ID, article)
 		require.NoError(t, err)
 
+		// Status and publish date only change through transitions
 		found, err := repo.FindByID(article.ID)
 		require.NoError(t, err)
 		assert.Equal(t, "Updated", found.Title)
-		assert.Equal(t, "PUBLISHED", found.Status)
-		assert.NotNil(t, found.PublishedAt)
+		assert.Equal(t, "DRAFT", found.Status)
+		assert.Nil(t, found.PublishedAt)
+		assert.Equal(t, "DRAFT", article.Status)
+	})
+
+	t.Run("Update checks the locked article", func(t *testing.T) {
+		article := &Article{Title: "Racing", Slug: fmt.Sprintf("racing-%d", time.Now().UnixNano()), Body: "v1", AuthorID: "123e4567-e89b-12d3-a456-426614174000", Status: "DRAFT"}
+		require.NoError(t, repo.Create(article))
+		_, err := repo.TransitionStatus(article.ID, StatusDraft, StatusInReview, article.AuthorID, nil)
+		require.NoError(t, err)
+
+		// A save that was authorized before the submit sees the new status under the lock
+		edit := &Article{Title: "Racing", Slug: article.Slug, Body: "v2"}
+		err = repo.UpdateWithRevision(article.ID, edit, &Revision{Title: "Racing", Body: "v2", EditorID: article.AuthorID}, func(current *Article) error {
+			if !CanArticle(Actor{UserID: article.AuthorID}, current, ActionEdit) {
+				return ErrForbidden
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		found, err := repo.FindByID(article.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "v1", found.Body)
+		assert.Equal(t, StatusInReview, found.Status)
 	})
 
 	t.Run("Soft Delete", func(t *testing.T) {
@@ -182,6 +210,155 @@ func TestRepository_Integration(t *testing.T) {
 		assert.Len(t, noArticles, 0)
 	})
 
+	t.Run("Revisions", func(t *testing.T) {
+		article := &Article{Title: "Revised", Slug: fmt.Sprintf("revised-%d", time.Now().UnixNano()), Body: "v1", AuthorID: "123e4567-e89b-12d3-a456-426614174000", Status: "DRAFT"}
+		require.NoError(t, repo.Create(article))
+
+		rev1 := &Revision{ArticleID: article.ID, Title: "Revised", Body: "v1", EditorID: article.AuthorID}
+		require.NoError(t, repo.AddRevision(rev1))
+		assert.Equal(t, 1, rev1.RevisionNumber)
+
+		rev2 := &Revision{ArticleID: article.ID, Title: "Revised again", Body: "v2", EditorID: "00000000-0000-0000-0000-000000000002"}
+		require.NoError(t, repo.AddRevision(rev2))
+		assert.Equal(t, 2, rev2.RevisionNumber)
+
+		revisions, err := repo.ListRevisions(article.ID)
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+		assert.Equal(t, 2, revisions[0].RevisionNumber)
+		assert.Empty(t, revisions[0].Body)
+
+		found, err := repo.GetRevision(article.ID, 1)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "v1", found.Body)
+		assert.Equal(t, article.AuthorID, found.EditorID)
+
+		missing, err := repo.GetRevision(article.ID, 99)
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+	})
+
+	t.Run("Create with revision", func(t *testing.T) {
+		article := &Article{Title: "First draft", Slug: fmt.Sprintf("first-draft-%d", time.Now().UnixNano()), Body: "v1", AuthorID: "123e4567-e89b-12d3-a456-426614174000", Status: "DRAFT"}
+		rev := &Revision{Title: article.Title, Body: article.Body, EditorID: article.AuthorID}
+		require.NoError(t, repo.CreateWithRevision(article, rev))
+		assert.Equal(t, article.ID, rev.ArticleID)
+		assert.Equal(t, 1, rev.RevisionNumber)
+
+		// A failed revision leaves no article behind
+		orphan := &Article{Title: "Orphan", Slug: fmt.Sprintf("orphan-%d", time.Now().UnixNano()), Body: "v1", AuthorID: "123e4567-e89b-12d3-a456-426614174000", Status: "DRAFT"}
+		require.Error(t, repo.CreateWithRevision(orphan, &Revision{Title: "Orphan", Body: "v1", EditorID: "not-a-uuid"}))
+		found, err := repo.FindBySlug(orphan.Slug)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("Concurrent saves", func(t *testing.T) {
+		article := &Article{Title: "Busy", Slug: fmt.Sprintf("busy-%d", time.Now().UnixNano()), Body: "v0", AuthorID: "123e4567-e89b-12d3-a456-426614174000", Status: "DRAFT"}
+		require.NoError(t, repo.Create(article))
+
+		// Each save gets its own revision number instead of colliding on the unique key
+		var wg sync.WaitGroup
+		errs := make(chan error, 5)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				edit := *article
+				edit.Body = fmt.Sprintf("v%d", i+1)
+				errs <- repo.UpdateWithRevision(article.ID, &edit, &Revision{Title: edit.Title, Body: edit.Body, EditorID: article.AuthorID}, func(*Article) error { return nil })
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		revisions, err := repo.ListRevisions(article.ID)
+		require.NoError(t, err)
+		require.Len(t, revisions, 5)
+		assert.Equal(t, 5, revisions[0].RevisionNumber)
+	})
+
+	t.Run("Scheduled publishing", func(t *testing.T) {
+		ctx := context.Background()
+		authorID := "123e4567-e89b-12d3-a456-426614174000"
+
+		due := &Article{Title: "Due", Slug: fmt.Sprintf("due-%d", time.Now().UnixNano()), Body: "Body", AuthorID: authorID, Status: StatusApproved}
+		upcoming := &Article{Title: "Upcoming", Slug: fmt.Sprintf("upcoming-%d", time.Now().UnixNano()), Body: "Body", AuthorID: authorID, Status: StatusApproved}
+		require.NoError(t, repo.Create(due))
+		require.NoError(t, repo.Create(upcoming))
+
+		past := time.Now().Add(-time.Minute)
+		future := time.Now().Add(time.Hour)
+		require.NoError(t, repo.SetSchedule(due.ID, &past, &future))
+		require.NoError(t, repo.SetSchedule(upcoming.ID, &future, nil))
+
+		scheduled, err := repo.FindAll(FilterOptions{Status: StatusScheduled, Limit: 50})
+		require.NoError(t, err)
+		ids := []string{}
+		for _, a := range scheduled {
+			ids = append(ids, a.ID)
+		}
+		assert.Contains(t, ids, due.ID)
+		assert.Contains(t, ids, upcoming.ID)
+
+		published, err := repo.PublishDue(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Contains(t, published, due.ID)
+		assert.NotContains(t, published, upcoming.ID)
+
+		found, err := repo.FindByID(due.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "PUBLISHED", found.Status)
+		assert.NotNil(t, found.PublishedAt)
+		assert.Nil(t, found.PublishAt)
+
+		archived, err := repo.ArchiveDue(ctx, future.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Contains(t, archived, due.ID)
+
+		found, err = repo.FindByID(due.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "ARCHIVED", found.Status)
+		assert.Nil(t, found.UnpublishAt)
+	})
+
+	t.Run("Workflow transitions and review notes", func(t *testing.T) {
+		authorID := "123e4567-e89b-12d3-a456-426614174000"
+		reviewerID := "00000000-0000-0000-0000-000000000002"
+		article := &Article{Title: "Workflow", Slug: fmt.Sprintf("workflow-%d", time.Now().UnixNano()), Body: "Body", AuthorID: authorID, Status: StatusDraft}
+		require.NoError(t, repo.Create(article))
+
+		_, err := repo.TransitionStatus(article.ID, StatusDraft, StatusInReview, authorID, nil)
+		require.NoError(t, err)
+
+		// A stale transition from the old status is rejected
+		_, err = repo.TransitionStatus(article.ID, StatusDraft, StatusInReview, authorID, nil)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		note := "Looks good"
+		moved, err := repo.TransitionStatus(article.ID, StatusInReview, StatusApproved, reviewerID, &note)
+		require.NoError(t, err)
+		assert.Equal(t, StatusApproved, moved.ToStatus)
+		require.NotNil(t, moved.ActorID)
+		assert.Equal(t, reviewerID, *moved.ActorID)
+
+		history, err := repo.ListTransitions(article.ID)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, StatusDraft, history[0].FromStatus)
+		assert.Equal(t, StatusApproved, history[1].ToStatus)
+
+		require.NoError(t, repo.AddReviewNote(&ReviewNote{ArticleID: article.ID, AuthorID: reviewerID, Body: "Private note"}))
+		notes, err := repo.ListReviewNotes(article.ID)
+		require.NoError(t, err)
+		require.Len(t, notes, 1)
+		assert.Equal(t, "Private note", notes[0].Body)
+	})
+
 	t.Run("Count", func(t *testing.T) {
 		article := &Article{Title: "Count Test", Slug: fmt.Sprintf("count-test-%d", time.Now().UnixNano()), Body: "Body", AuthorID: "123e4567-e89b-12d3-a456-426614174000", Status: "DRAFT"}
 		require.NoError(t, repo.Create(article))
@@ -194,4 +371,298 @@ func TestRepository_Integration(t *testing.T) {
 		require.NoError(t, err)
 		assert.Greater(t, countDrafts, 0)
 	})
+
+	t.Run("Interaction counters", func(t *testing.T) {
+		article := &Article{Title: "Counters", Slug: fmt.Sprintf("counters-%d", time.Now().UnixNano()), Body: "Body", AuthorID: "123e4567-e89b-12d3-a456-426614174000", Status: "PUBLISHED"}
+		require.NoError(t, repo.Create(article))
+		reader1 := "00000000-0000-0000-0000-000000000001"
+		reader2 := "00000000-0000-0000-0000-000000000002"
+
+		require.NoError(t, repo.AddLike(&ArticleLike{ArticleID: article.ID, UserID: reader1, IsLike: true}))
+		require.NoError(t, repo.AddLike(&ArticleLike{ArticleID: article.ID, UserID: reader2, IsLike: true}))
+		require.NoError(t, repo.AddLike(&ArticleLike{ArticleID: article.ID, UserID: reader2, IsLike: false}))
+
+		approved := &Comment{ArticleID: article.ID, UserID: reader1, Body: "Visible", Status: CommentApproved}
+		require.NoError(t, repo.AddComment(approved))
+		require.NoError(t, repo.AddComment(&Comment{ArticleID: article.ID, UserID: reader2, Body: "Held", Status: CommentPending}))
+
+		found, err := repo.FindByID(article.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, found.LikesCount)
+		assert.Equal(t, 1, found.DislikesCount)
+		assert.Equal(t, 1, found.CommentsCount)
+
+		require.NoError(t, repo.RemoveLike(article.ID, reader1))
+		require.NoError(t, repo.DeleteComment(approved.ID, reader1))
+
+		found, err = repo.FindByID(article.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, found.LikesCount)
+		assert.Equal(t, 0, found.CommentsCount)
+
+		likes, err := repo.GetUserLikes([]string{article.ID}, reader2)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{article.ID: false}, likes)
+	})
+
+	t.Run("Daily stats", func(t *testing.T) {
+		article := &Article{Title: "Stats", Slug: fmt.Sprintf("stats-%d", time.Now().UnixNano()), Body: "Body", AuthorID: "123e4567-e89b-12d3-a456-426614174000", Status: "PUBLISHED"}
+		require.NoError(t, repo.Create(article))
+		today := utcDay(time.Now())
+
+		salt, err := repo.ViewSalt(today, []byte("first"))
+		require.NoError(t, err)
+		again, err := repo.ViewSalt(today, []byte("second"))
+		require.NoError(t, err)
+		assert.Equal(t, salt, again)
+
+		require.NoError(t, repo.RecordView(article.ID, today, visitorHash(salt, "anon:a")))
+		require.NoError(t, repo.RecordView(article.ID, today, visitorHash(salt, "anon:a")))
+		require.NoError(t, repo.RecordView(article.ID, today, visitorHash(salt, "anon:b")))
+		require.NoError(t, repo.AddLike(&ArticleLike{ArticleID: article.ID, UserID: "00000000-0000-0000-0000-000000000001", IsLike: true}))
+
+		require.NoError(t, repo.RollupStats(context.Background(), today.AddDate(0, 0, -1), today))
+		days, err := repo.ListDailyStats(article.ID, today.AddDate(0, 0, -2), today)
+		require.NoError(t, err)
+		require.Len(t, days, 3)
+		assert.Equal(t, DailyStats{Date: today.AddDate(0, 0, -2).Format(statsDateLayout)}, days[0])
+		assert.Equal(t, 2, days[2].Views)
+		assert.Equal(t, 1, days[2].Likes)
+
+		require.NoError(t, repo.RemoveLike(article.ID, "00000000-0000-0000-0000-000000000001"))
+		require.NoError(t, repo.RollupStats(context.Background(), today.AddDate(0, 0, -1), today))
+		days, err = repo.ListDailyStats(article.ID, today, today)
+		require.NoError(t, err)
+		require.Len(t, days, 1)
+		assert.Equal(t, 2, days[0].Views)
+		assert.Equal(t, 0, days[0].Likes)
+	})
+
+	t.Run("Trending", func(t *testing.T) {
+		author := "123e4567-e89b-12d3-a456-426614174000"
+		liked := &Article{Title: "Liked", Slug: fmt.Sprintf("liked-%d", time.Now().UnixNano()), Body: "Body", AuthorID: author, Status: "PUBLISHED"}
+		require.NoError(t, repo.Create(liked))
+		disliked := &Article{Title: "Disliked", Slug: fmt.Sprintf("disliked-%d", time.Now().UnixNano()), Body: "Body", AuthorID: author, Status: "PUBLISHED"}
+		require.NoError(t, repo.Create(disliked))
+
+		require.NoError(t, repo.AddLike(&ArticleLike{ArticleID: liked.ID, UserID: "00000000-0000-0000-0000-000000000001", IsLike: true}))
+		require.NoError(t, repo.AddComment(&Comment{ArticleID: liked.ID, UserID: "00000000-0000-0000-0000-000000000002", Body: "Nice", Status: CommentApproved}))
+		require.NoError(t, repo.AddLike(&ArticleLike{ArticleID: disliked.ID, UserID: "00000000-0000-0000-0000-000000000001", IsLike: false}))
+
+		window, _ := trendingWindow("24h")
+		require.NoError(t, repo.RefreshTrending(context.Background(), window, time.Now()))
+		trending, err := repo.ListTrending("24h", 50)
+		require.NoError(t, err)
+
+		var ids []string
+		for _, a := range trending {
+			ids = append(ids, a.ID)
+			if a.ID == liked.ID {
+				assert.InDelta(t, likeWeight+commentWeight, a.Score, 0.1)
+			}
+		}
+		assert.Contains(t, ids, liked.ID)
+		assert.NotContains(t, ids, disliked.ID)
+
+		// A replica refreshing the period at the same time makes this run skip
+		other, err := db.Beginx()
+		require.NoError(t, err)
+		_, err = other.Exec(`SELECT pg_advisory_xact_lock(hashtext('article_trending:' || $1))`, window.Name)
+		require.NoError(t, err)
+		require.NoError(t, repo.RefreshTrending(context.Background(), window, time.Now().Add(48*time.Hour)))
+		require.NoError(t, other.Rollback())
+		trending, err = repo.ListTrending("24h", 50)
+		require.NoError(t, err)
+		assert.NotEmpty(t, trending)
+
+		// Activity older than a day decays out of the 24h window
+		require.NoError(t, repo.RefreshTrending(context.Background(), window, time.Now().Add(48*time.Hour)))
+		trending, err = repo.ListTrending("24h", 50)
+		require.NoError(t, err)
+		assert.Empty(t, trending)
+	})
+
+	t.Run("Search by language", func(t *testing.T) {
+		author := "123e4567-e89b-12d3-a456-426614174000"
+		ru := &Article{Title: "Нейронные сети", Slug: fmt.Sprintf("ru-%d", time.Now().UnixNano()), Body: "Обучение нейронных сетей", AuthorID: author, Status: "PUBLISHED", Language: "ru"}
+		require.NoError(t, repo.Create(ru))
+		kk := &Article{Title: "Жасанды интеллект", Slug: fmt.Sprintf("kk-%d", time.Now().UnixNano()), Body: "Жасанды интеллект туралы", AuthorID: author, Status: "PUBLISHED", Language: "kk"}
+		require.NoError(t, repo.Create(kk))
+
+		// Russian stemming matches another word form
+		page, err := repo.Search(SearchOptions{Query: "нейронная сеть", Language: "ru", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, page.Total)
+		require.Len(t, page.Results, 1)
+		assert.Equal(t, ru.ID, page.Results[0].ID)
+		assert.Equal(t, "ru", page.Results[0].Language)
+
+		page, err = repo.Search(SearchOptions{Query: "интеллект", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, page.Results, 1)
+		assert.Equal(t, kk.ID, page.Results[0].ID)
+
+		page, err = repo.Search(SearchOptions{Query: "интеллект", Language: "en", Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, page.Results)
+	})
+
+	t.Run("Search facets and filters", func(t *testing.T) {
+		author := "123e4567-e89b-12d3-a456-426614174000"
+		var first, second string
+		require.NoError(t, db.Get(&first, `INSERT INTO categories (code, name) VALUES ($1, '{"en":"Compilers"}') RETURNING id`, fmt.Sprintf("compilers-%d", time.Now().UnixNano())))
+		require.NoError(t, db.Get(&second, `INSERT INTO categories (code, name) VALUES ($1, '{"en":"Runtimes"}') RETURNING id`, fmt.Sprintf("runtimes-%d", time.Now().UnixNano())))
+
+		march := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+		may := time.Date(2025, 5, 20, 12, 0, 0, 0, time.UTC)
+		create := func(title, category string, published time.Time, tags ...string) {
+			a := &Article{Title: title, Slug: fmt.Sprintf("facet-%d", time.Now().UnixNano()), Body: "Garbage collection in zigzag runtimes", CategoryID: &category, AuthorID: author, Status: "PUBLISHED", PublishedAt: &published}
+			require.NoError(t, repo.Create(a))
+			for _, code := range tags {
+				_, err := db.Exec(`INSERT INTO tags (code) VALUES ($1) ON CONFLICT (code) DO NOTHING`, code)
+				require.NoError(t, err)
+				_, err = db.Exec(`INSERT INTO article_tags (article_id, tag_id) SELECT $1, id FROM tags WHERE code = $2`, a.ID, code)
+				require.NoError(t, err)
+			}
+		}
+		create("Zigzag one", first, march, "zig-gc", "zig-perf")
+		create("Zigzag two", first, may, "zig-gc")
+		create("Zigzag three", second, may, "zig-perf")
+
+		page, err := repo.Search(SearchOptions{Query: "zigzag", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 3, page.Total)
+		assert.Equal(t, []FacetBucket{{Value: first, Label: "Compilers", Count: 2}, {Value: second, Label: "Runtimes", Count: 1}}, page.Facets.Categories)
+		assert.Equal(t, []FacetBucket{{Value: "2025-05", Count: 2}, {Value: "2025-03", Count: 1}}, page.Facets.Months)
+
+		// Categories are counted without the category filter
+		page, err = repo.Search(SearchOptions{Query: "zigzag", CategoryIDs: []string{first}, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 2, page.Total)
+		assert.Len(t, page.Facets.Categories, 2)
+
+		page, err = repo.Search(SearchOptions{Query: "zigzag", Tags: []string{"zig-gc", "zig-perf"}, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 3, page.Total)
+
+		page, err = repo.Search(SearchOptions{Query: "zigzag", Tags: []string{"zig-gc", "zig-perf"}, MatchAllTags: true, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, page.Total)
+
+		page, err = repo.Search(SearchOptions{Query: "zigzag", PublishedFrom: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), PublishedTo: time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC), Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 2, page.Total)
+		assert.Len(t, page.Facets.Months, 2)
+	})
+
+	t.Run("Search highlights escape markup", func(t *testing.T) {
+		author := "123e4567-e89b-12d3-a456-426614174000"
+		article := &Article{Title: `<img src=x onerror=alert(1)> Wombat`, Slug: fmt.Sprintf("xss-%d", time.Now().UnixNano()), Body: `Wombat "tips" & <script>tricks</script>`, AuthorID: author, Status: "PUBLISHED"}
+		require.NoError(t, repo.Create(article))
+
+		page, err := repo.Search(SearchOptions{Query: "wombat", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, page.Results, 1)
+		assert.Equal(t, `&lt;img src=x onerror=alert(1)&gt; <mark>Wombat</mark>`, page.Results[0].Title)
+		assert.NotContains(t, page.Results[0].Excerpt, "<script>")
+		assert.Contains(t, page.Results[0].Excerpt, "&lt;script&gt;")
+	})
+
+	t.Run("Search query syntax", func(t *testing.T) {
+		author := "123e4567-e89b-12d3-a456-426614174000"
+		inTitle := &Article{Title: "Quokka agents", Slug: fmt.Sprintf("syntax-%d", time.Now().UnixNano()), Body: "Prompt engineering for assistants", AuthorID: author, Status: "PUBLISHED"}
+		require.NoError(t, repo.Create(inTitle))
+		inBody := &Article{Title: "Assistants", Slug: fmt.Sprintf("syntax-%d", time.Now().UnixNano()), Body: "Quokka agents built with openai and engineering prompts", AuthorID: author, Status: "PUBLISHED"}
+		require.NoError(t, repo.Create(inBody))
+
+		// Title matches rank first and both title and excerpt are highlighted
+		page, err := repo.Search(SearchOptions{Query: "quokka", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, page.Results, 2)
+		assert.Equal(t, inTitle.ID, page.Results[0].ID)
+		assert.Equal(t, "<mark>Quokka</mark> agents", page.Results[0].Title)
+		assert.Contains(t, page.Results[1].Excerpt, "<mark>Quokka</mark>")
+
+		page, err = repo.Search(SearchOptions{Query: "title:quokka", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, page.Results, 1)
+		assert.Equal(t, inTitle.ID, page.Results[0].ID)
+
+		page, err = repo.Search(SearchOptions{Query: "quokka -openai", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, page.Results, 1)
+		assert.Equal(t, inTitle.ID, page.Results[0].ID)
+
+		page, err = repo.Search(SearchOptions{Query: `quokka "prompt engineering"`, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, page.Results, 1)
+		assert.Equal(t, inTitle.ID, page.Results[0].ID)
+
+		page, err = repo.Search(SearchOptions{Query: "quokka -author:" + author, Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, page.Results)
+	})
+
+	t.Run("Search syntax counts and facets", func(t *testing.T) {
+		author := "123e4567-e89b-12d3-a456-426614174000"
+		var category string
+		require.NoError(t, db.Get(&category, `INSERT INTO categories (code, name) VALUES ($1, '{"en":"Marsupials"}') RETURNING id`, fmt.Sprintf("marsupials-%d", time.Now().UnixNano())))
+		june := time.Date(2025, 6, 5, 12, 0, 0, 0, time.UTC)
+		create := func(title, body string) *Article {
+			a := &Article{Title: title, Slug: fmt.Sprintf("wallaby-%d", time.Now().UnixNano()), Body: body, CategoryID: &category, AuthorID: author, Status: "PUBLISHED", PublishedAt: &june}
+			require.NoError(t, repo.Create(a))
+			_, err := db.Exec(`INSERT INTO tags (code) VALUES ('wallaby-care') ON CONFLICT (code) DO NOTHING`)
+			require.NoError(t, err)
+			_, err = db.Exec(`INSERT INTO article_tags (article_id, tag_id) SELECT $1, id FROM tags WHERE code = 'wallaby-care'`, a.ID)
+			require.NoError(t, err)
+			return a
+		}
+		kept := create("Wallaby feeding", "Grass and leaves")
+		create("Wallaby pellets", "Commercial pellets for feeding")
+
+		page, err := repo.Search(SearchOptions{Query: "wallaby -pellets", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, page.Results, 1)
+		assert.Equal(t, kept.ID, page.Results[0].ID)
+		assert.Equal(t, 1, page.Total)
+		assert.Equal(t, []FacetBucket{{Value: category, Label: "Marsupials", Count: 1}}, page.Facets.Categories)
+		assert.Equal(t, []FacetBucket{{Value: "wallaby-care", Label: "wallaby-care", Count: 1}}, page.Facets.Tags)
+		assert.Equal(t, []FacetBucket{{Value: "2025-06", Count: 1}}, page.Facets.Months)
+
+		// Articles matched by meaning only still honour exclusions in hybrid mode
+		_, err = db.Exec(`
+			INSERT INTO article_embeddings (article_id, chunk, model, content, embedding)
+			SELECT a.id, 0, 'test-model', a.body, $1 FROM articles a WHERE a.category_id = $2
+		`, pq.Array([]float32{1, 0}), category)
+		require.NoError(t, err)
+		page, err = repo.Search(SearchOptions{Query: "marsupial -pellets", Mode: SearchModeHybrid, Embedding: []float32{1, 0}, EmbeddingModel: "test-model", MinSimilarity: 0.5, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, page.Results, 1)
+		assert.Equal(t, kept.ID, page.Results[0].ID)
+		assert.Equal(t, 1, page.Total)
+		assert.Len(t, page.Facets.Categories, 1)
+	})
+
+	t.Run("Suggest and correct", func(t *testing.T) {
+		author := "123e4567-e89b-12d3-a456-426614174000"
+		article := &Article{Title: "Kubernetes operators explained", Slug: fmt.Sprintf("k8s-%d", time.Now().UnixNano()), Body: "Writing kubernetes operators in Go", AuthorID: author, Status: "PUBLISHED"}
+		require.NoError(t, repo.Create(article))
+		require.NoError(t, repo.RefreshSearchTerms(context.Background()))
+
+		suggestions, err := repo.Suggest("kubern", "en", 5)
+		require.NoError(t, err)
+		require.NotEmpty(t, suggestions.Articles)
+		assert.Equal(t, article.ID, suggestions.Articles[0].Value)
+		assert.Equal(t, article.Slug, suggestions.Articles[0].Slug)
+
+		// A typo still completes through trigram similarity
+		suggestions, err = repo.Suggest("kubernetis", "en", 5)
+		require.NoError(t, err)
+		require.NotEmpty(t, suggestions.Articles)
+		assert.Equal(t, article.ID, suggestions.Articles[0].Value)
+
+		corrections, err := repo.CorrectWords([]string{"kubernetis", "operators", "zzzzqqq"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"kubernetes", "operators", ""}, corrections)
+	})
 }
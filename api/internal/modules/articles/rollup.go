@@ -0,0 +1,62 @@
+package articles
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// StatsRollup periodically aggregates recorded views, likes and comments into daily stats
+// and logged searches into daily search stats, and then recomputes the trending scores and
+// the words used for spelling corrections. Yesterday is recomputed as well so the first run after midnight completes it.
+type StatsRollup struct {
+	repo     *Repository
+	interval time.Duration
+}
+
+func NewStatsRollup(repo *Repository, interval time.Duration) *StatsRollup {
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+	return &StatsRollup{repo: repo, interval: interval}
+}
+
+// Run rolls up stats every interval until the context is cancelled
+func (s *StatsRollup) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	log.Printf("[STATS] Started with interval %s", s.interval)
+	for {
+		if err := s.RunOnce(ctx); err != nil {
+			log.Printf("[STATS] Rollup failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("[STATS] Stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce recomputes the stats and search stats of yesterday and today, the score of every
+// trending window and the words of published articles. Searches logged before yesterday are
+// dropped once rolled up.
+func (s *StatsRollup) RunOnce(ctx context.Context) error {
+	now := time.Now()
+	today := utcDay(now)
+	if err := s.repo.RollupStats(ctx, today.AddDate(0, 0, -1), today); err != nil {
+		return err
+	}
+	if err := s.repo.RollupSearchStats(ctx, today.AddDate(0, 0, -1)); err != nil {
+		return err
+	}
+	for _, window := range TrendingWindows {
+		if err := s.repo.RefreshTrending(ctx, window, now); err != nil {
+			return err
+		}
+	}
+	return s.repo.RefreshSearchTerms(ctx)
+}
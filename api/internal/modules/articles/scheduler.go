@@ -0,0 +1,64 @@
+package articles
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Scheduler periodically publishes and archives articles whose scheduled time has passed.
+// It is safe to run in every API replica: due rows are claimed with FOR UPDATE SKIP LOCKED.
+type Scheduler struct {
+	repo     *Repository
+	interval time.Duration
+}
+
+func NewScheduler(repo *Repository, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &Scheduler{repo: repo, interval: interval}
+}
+
+// Run processes due articles every interval until the context is cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	log.Printf("[SCHEDULER] Started with interval %s", s.interval)
+	for {
+		if _, _, err := s.RunOnce(ctx); err != nil {
+			log.Printf("[SCHEDULER] Run failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("[SCHEDULER] Stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce promotes due drafts to PUBLISHED and due published articles to ARCHIVED
+func (s *Scheduler) RunOnce(ctx context.Context) (published, archived []string, err error) {
+	now := time.Now()
+
+	published, err = s.repo.PublishDue(ctx, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, id := range published {
+		log.Printf("[SCHEDULER] Published article %s", id)
+	}
+
+	archived, err = s.repo.ArchiveDue(ctx, now)
+	if err != nil {
+		return published, nil, err
+	}
+	for _, id := range archived {
+		log.Printf("[SCHEDULER] Archived article %s", id)
+	}
+
+	return published, archived, nil
+}
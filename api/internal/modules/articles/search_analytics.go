@@ -0,0 +1,259 @@
+package articles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSearchNotFound      = errors.New("search not found")
+	ErrInvalidSearchReport = errors.New("report must be top-queries, zero-results or click-through")
+)
+
+// Search analytics reports
+const (
+	// SearchReportTopQueries ranks queries by how often they are searched
+	SearchReportTopQueries = "top-queries"
+	// SearchReportZeroResults ranks queries by how often they find nothing
+	SearchReportZeroResults = "zero-results"
+	// SearchReportClickThrough ranks frequent queries from the lowest click-through rate
+	SearchReportClickThrough = "click-through"
+)
+
+// maxLoggedQueryLength bounds the length of a logged query in characters
+const maxLoggedQueryLength = 200
+
+// repeatSearchWindow is how long the same query from the same reader counts as one search
+const repeatSearchWindow = 30 * time.Minute
+
+// defaultMinSearches is how often a query must be searched to appear in the click-through report
+const defaultMinSearches = 5
+
+// Personal data that readers sometimes type into the search box is masked before logging
+var (
+	queryEmailPattern  = regexp.MustCompile(`[^\s@]+@[^\s@]+\.[^\s@]+`)
+	queryIDPattern     = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	queryNumberPattern = regexp.MustCompile(`\+?\d[\d\-() ]{5,}\d`)
+)
+
+// SearchQueryStats are the searches of one normalized query over a date range. CTR is the
+// share of searches after which at least one result was opened.
+type SearchQueryStats struct {
+	Query           string  `db:"query" json:"query"`
+	Searches        int     `db:"searches" json:"searches"`
+	ZeroResults     int     `db:"zero_results" json:"zero_results"`
+	ClickedSearches int     `db:"clicked_searches" json:"clicked_searches"`
+	Clicks          int     `db:"clicks" json:"clicks"`
+	CTR             float64 `db:"ctr" json:"ctr"`
+}
+
+// SearchAnalyticsReport is one search analytics report over a date range
+type SearchAnalyticsReport struct {
+	Report  string             `json:"report"`
+	From    string             `json:"from"`
+	To      string             `json:"to"`
+	Queries []SearchQueryStats `json:"queries"`
+}
+
+// normalizeSearchQuery lower-cases a query, collapses whitespace, masks e-mail addresses,
+// IDs and phone-like numbers, and truncates it
+func normalizeSearchQuery(query string) string {
+	q := strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	q = queryEmailPattern.ReplaceAllString(q, "<email>")
+	q = queryIDPattern.ReplaceAllString(q, "<id>")
+	q = queryNumberPattern.ReplaceAllString(q, "<number>")
+	if runes := []rune(q); len(runes) > maxLoggedQueryLength {
+		q = strings.TrimSpace(string(runes[:maxLoggedQueryLength]))
+	}
+	return q
+}
+
+// RecordSearch logs a search and returns its ID. The same query from the same reader within
+// repeatSearchWindow is counted once and returns the ID of the first search.
+func (r *Repository) RecordSearch(query string, results int, mode, visitorHash string) (string, error) {
+	sql := `
+		WITH existing AS (
+			SELECT id FROM search_queries
+			WHERE visitor_hash = $4 AND query = $1 AND created_at > NOW() - $5::float8 * INTERVAL '1 second'
+			ORDER BY created_at DESC
+			LIMIT 1
+		), inserted AS (
+			INSERT INTO search_queries (query, results, mode, visitor_hash)
+			SELECT $1, $2, $3, $4
+			WHERE NOT EXISTS (SELECT 1 FROM existing)
+			RETURNING id
+		)
+		SELECT id FROM inserted
+		UNION ALL
+		SELECT id FROM existing
+		LIMIT 1
+	`
+	var id string
+	err := r.db.Get(&id, sql, query, results, mode, visitorHash, repeatSearchWindow.Seconds())
+	return id, err
+}
+
+// RecordSearchClick records that a result of a search was opened, once per search and article
+func (r *Repository) RecordSearchClick(searchID, articleID string, position int) error {
+	query := `
+		WITH target AS (
+			SELECT s.id AS search_id, a.id AS article_id
+			FROM search_queries s
+			JOIN articles a ON a.id = $2
+			WHERE s.id = $1
+		), inserted AS (
+			INSERT INTO search_clicks (search_id, article_id, position)
+			SELECT search_id, article_id, $3 FROM target
+			ON CONFLICT DO NOTHING
+		)
+		SELECT COUNT(*) FROM target
+	`
+	var found int
+	if err := r.db.Get(&found, query, searchID, articleID, position); err != nil {
+		return err
+	}
+	if found == 0 {
+		return ErrSearchNotFound
+	}
+	return nil
+}
+
+// RollupSearchStats recomputes the daily search stats of every day since the given one,
+// then drops the searches before it
+func (r *Repository) RollupSearchStats(ctx context.Context, since time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM search_daily_stats WHERE day >= $1`, since); err != nil {
+		return err
+	}
+
+	rollup := `
+		INSERT INTO search_daily_stats (day, query, searches, zero_results, clicked_searches, clicks)
+		SELECT (s.created_at AT TIME ZONE 'UTC')::date, s.query,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE s.results = 0),
+			COUNT(*) FILTER (WHERE c.clicks > 0),
+			COALESCE(SUM(c.clicks), 0)
+		FROM search_queries s
+		LEFT JOIN (
+			SELECT search_id, COUNT(*) AS clicks FROM search_clicks GROUP BY search_id
+		) c ON c.search_id = s.id
+		WHERE (s.created_at AT TIME ZONE 'UTC')::date >= $1
+		GROUP BY 1, 2
+	`
+	if _, err := tx.ExecContext(ctx, rollup, since); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM search_queries WHERE (created_at AT TIME ZONE 'UTC')::date < $1`, since); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListSearchQueryStats sums the daily search stats of each query over a date range for a report
+func (r *Repository) ListSearchQueryStats(report string, from, to time.Time, minSearches, limit int) ([]SearchQueryStats, error) {
+	having := `HAVING SUM(searches) >= $3`
+	order := `searches DESC, query`
+	switch report {
+	case SearchReportZeroResults:
+		having += ` AND SUM(zero_results) > 0`
+		order = `zero_results DESC, searches DESC, query`
+	case SearchReportClickThrough:
+		order = `ctr ASC, searches DESC, query`
+	}
+
+	query := fmt.Sprintf(`
+		SELECT query, searches, zero_results, clicked_searches, clicks,
+			clicked_searches::float8 / GREATEST(searches, 1) AS ctr
+		FROM (
+			SELECT query, SUM(searches) AS searches, SUM(zero_results) AS zero_results,
+				SUM(clicked_searches) AS clicked_searches, SUM(clicks) AS clicks
+			FROM search_daily_stats
+			WHERE day BETWEEN $1 AND $2
+			GROUP BY query
+			%s
+		) totals
+		ORDER BY %s
+		LIMIT $4
+	`, having, order)
+	stats := []SearchQueryStats{}
+	err := r.db.Select(&stats, query, from, to, minSearches, limit)
+	return stats, err
+}
+
+// LogSearch records a search run by a reader and returns its ID for click tracking, or an
+// empty ID when the query is empty once normalized. The visitor key is only kept as a
+// hash salted with a secret that changes daily.
+func (s *Service) LogSearch(query string, results int, mode, visitor string) (string, error) {
+	normalized := normalizeSearchQuery(query)
+	if normalized == "" {
+		return "", nil
+	}
+	salt, err := s.salts.get(s.repo, utcDay(time.Now()))
+	if err != nil {
+		return "", err
+	}
+	return s.repo.RecordSearch(normalized, results, mode, visitorHash(salt, visitor))
+}
+
+// RecordSearchClick records that a reader opened a result of a logged search
+func (s *Service) RecordSearchClick(searchID, articleID string, position int) error {
+	if _, err := uuid.Parse(searchID); err != nil {
+		return ErrSearchNotFound
+	}
+	if _, err := uuid.Parse(articleID); err != nil {
+		return ErrArticleNotFound
+	}
+	if position < 0 {
+		position = 0
+	}
+	return s.repo.RecordSearchClick(searchID, articleID, position)
+}
+
+// SearchAnalytics retrieves a search analytics report for editors. Dates are YYYY-MM-DD in
+// UTC and default to the last 30 days; the click-through report only ranks queries searched
+// at least minSearches times.
+func (s *Service) SearchAnalytics(actor Actor, report, from, to string, minSearches, limit int) (*SearchAnalyticsReport, error) {
+	if !actor.CanManage {
+		return nil, ErrForbidden
+	}
+	switch report {
+	case SearchReportTopQueries, SearchReportZeroResults, SearchReportClickThrough:
+	default:
+		return nil, ErrInvalidSearchReport
+	}
+	start, end, err := parseStatsRange(from, to, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if report != SearchReportClickThrough {
+		minSearches = 1
+	} else if minSearches <= 0 {
+		minSearches = defaultMinSearches
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	queries, err := s.repo.ListSearchQueryStats(report, start, end, minSearches, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchAnalyticsReport{
+		Report:  report,
+		From:    start.Format(statsDateLayout),
+		To:      end.Format(statsDateLayout),
+		Queries: queries,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package articles
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSearchQuery(t *testing.T) {
+	assert.Equal(t, "prompt engineering", normalizeSearchQuery("  Prompt \t ENGINEERING "))
+	assert.Equal(t, "contact <email> now", normalizeSearchQuery("contact Jane.Doe@example.com now"))
+	assert.Equal(t, "article <id>", normalizeSearchQuery("article 123E4567-e89b-12d3-a456-426614174000"))
+	assert.Equal(t, "call <number>", normalizeSearchQuery("call +7 (701) 123-45-67"))
+	assert.Equal(t, "go 1.22 in 2025", normalizeSearchQuery("Go 1.22 in 2025"))
+	assert.Equal(t, "", normalizeSearchQuery("   "))
+	assert.Len(t, []rune(normalizeSearchQuery(strings.Repeat("ж", 300))), maxLoggedQueryLength)
+}
+
+func TestService_SearchAnalytics_Validation(t *testing.T) {
+	service := &Service{}
+	_, err := service.SearchAnalytics(Actor{UserID: "reader"}, SearchReportTopQueries, "", "", 0, 0)
+	assert.ErrorIs(t, err, ErrForbidden)
+
+	editor := Actor{UserID: "editor", CanManage: true}
+	_, err = service.SearchAnalytics(editor, "popular", "", "", 0, 0)
+	assert.ErrorIs(t, err, ErrInvalidSearchReport)
+
+	_, err = service.SearchAnalytics(editor, SearchReportZeroResults, "2025-02-01", "2025-01-01", 0, 0)
+	assert.ErrorIs(t, err, ErrInvalidStatsRange)
+}
+
+func TestService_RecordSearchClick_InvalidIDs(t *testing.T) {
+	service := &Service{}
+	assert.ErrorIs(t, service.RecordSearchClick("not-a-search", "123e4567-e89b-12d3-a456-426614174000", 0), ErrSearchNotFound)
+	assert.ErrorIs(t, service.RecordSearchClick("123e4567-e89b-12d3-a456-426614174000", "not-an-article", 0), ErrArticleNotFound)
+}
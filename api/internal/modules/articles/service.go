@@ -1,31 +1,90 @@
 package articles
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/ai-dala/api/internal/slugify"
+	"github.com/google/uuid"
+)
+
+// Reactions of a reader to an article
+const (
+	ReactionLike    = "like"
+	ReactionDislike = "dislike"
+)
+
+var (
+	ErrArticleNotFound   = errors.New("article not found")
+	ErrCommentNotFound   = errors.New("comment not found")
+	ErrRevisionNotFound  = errors.New("revision not found")
+	ErrInvalidSchedule   = errors.New("invalid schedule")
+	ErrInvalidStatus     = errors.New("invalid status")
+	ErrInvalidTransition = errors.New("invalid status transition")
+	ErrForbidden         = errors.New("forbidden")
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrCommentTooDeep    = errors.New("maximum reply depth reached")
+	ErrEmptyComment      = errors.New("comment body cannot be empty")
+	ErrInvalidLanguage   = errors.New("language must be en, ru or kk")
+	ErrQueryTooShort     = errors.New("query too short")
+	// ErrInvalidSearchFilter reports a malformed category or author ID or a bad publication date range
+	ErrInvalidSearchFilter = errors.New("invalid search filter")
+	ErrInvalidSearchMode   = errors.New("mode must be keyword, semantic or hybrid")
+	// ErrSemanticSearchUnavailable reports a semantic or hybrid search while embeddings are disabled
+	ErrSemanticSearchUnavailable = errors.New("semantic search is not available")
 )
 
+// Languages lists the languages articles are written in
+var Languages = []string{"en", "ru", "kk"}
+
+// validLanguage reports whether lang is one of Languages
+func validLanguage(lang string) bool {
+	for _, l := range Languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
 type Service struct {
-	repo *Repository
+	repo     *Repository
+	config   Config
+	filters  []CommentFilter
+	salts    *viewSalts
+	related  *relatedCache
+	embedder Embedder
+	// embedding tracks the background embeddings started by saves
+	embedding sync.WaitGroup
 }
 
-func NewService(repo *Repository) *Service {
-	return &Service{repo: repo}
+func NewService(repo *Repository, config Config) *Service {
+	return &Service{
+		repo:     repo,
+		config:   config,
+		filters:  NewCommentFilters(config.Moderation),
+		salts:    &viewSalts{},
+		related:  &relatedCache{},
+		embedder: NewEmbedder(config.Embeddings),
+	}
 }
 
 // Create creates a new article
 func (s *Service) Create(article *Article) error {
 	// Set default status if not provided
 	if article.Status == "" {
-		article.Status = "DRAFT"
+		article.Status = StatusDraft
 	}
 
 	// Generate slug if empty
 	if article.Slug == "" {
-		baseSlug := generateSlug(article.Title)
+		baseSlug := generateSlug(article.Title, uuid.NewString())
 		article.Slug = baseSlug
 
 		// Ensure uniqueness
@@ -43,11 +102,21 @@ func (s *Service) Create(article *Article) error {
 	}
 
 	// Validate status
-	if article.Status != "DRAFT" && article.Status != "PUBLISHED" && article.Status != "ARCHIVED" {
-		return errors.New("invalid status: must be DRAFT, PUBLISHED, or ARCHIVED")
+	if !validStatuses[article.Status] {
+		return ErrInvalidStatus
+	}
+	if article.Language == "" {
+		article.Language = "en"
+	}
+	if !validLanguage(article.Language) {
+		return ErrInvalidLanguage
 	}
 
-	return s.repo.Create(article)
+	if err := s.repo.CreateWithRevision(article, newRevision(article, article.AuthorID)); err != nil {
+		return err
+	}
+	s.embedArticle(article.ID)
+	return nil
 }
 
 // FindByID retrieves an article by ID
@@ -84,16 +153,17 @@ func (s *Service) FindAll(status, categoryID, authorID string, tags []string, li
 	return articles, count, nil
 }
 
-// Update updates an existing article
-func (s *Service) Update(id string, article *Article) error {
-	// Validate status if provided
-	if article.Status != "" && article.Status != "DRAFT" && article.Status != "PUBLISHED" && article.Status != "ARCHIVED" {
-		return errors.New("invalid status: must be DRAFT, PUBLISHED, or ARCHIVED")
+// Update updates the content of an existing article and records a revision attributed to
+// the actor. The edit policy is checked again under the article lock, against its current status.
+func (s *Service) Update(id string, article *Article, actor Actor) error {
+	// An empty language keeps the current one
+	if article.Language != "" && !validLanguage(article.Language) {
+		return ErrInvalidLanguage
 	}
 
 	// Generate slug if empty and title is present
 	if article.Slug == "" && article.Title != "" {
-		baseSlug := generateSlug(article.Title)
+		baseSlug := generateSlug(article.Title, id)
 		article.Slug = baseSlug
 
 		// Ensure uniqueness
@@ -111,24 +181,252 @@ func (s *Service) Update(id string, article *Article) error {
 		}
 	}
 
-	return s.repo.Update(id, article)
+	err := s.repo.UpdateWithRevision(id, article, newRevision(article, actor.UserID), func(current *Article) error {
+		if !CanArticle(actor, current, ActionEdit) {
+			return ErrForbidden
+		}
+		return nil
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrArticleNotFound
+	}
+	if err != nil {
+		return err
+	}
+	s.embedArticle(id)
+	return nil
+}
+
+// newRevision snapshots the article content as a revision by the editor
+func newRevision(article *Article, editorID string) *Revision {
+	return &Revision{
+		Title:      article.Title,
+		Body:       article.Body,
+		CategoryID: article.CategoryID,
+		EditorID:   editorID,
+	}
+}
+
+// ListRevisions retrieves the revision history of an article
+func (s *Service) ListRevisions(articleID string) ([]Revision, error) {
+	return s.repo.ListRevisions(articleID)
+}
+
+// GetRevision retrieves a single revision of an article
+func (s *Service) GetRevision(articleID string, number int) (*Revision, error) {
+	rev, err := s.repo.GetRevision(articleID, number)
+	if err != nil {
+		return nil, err
+	}
+	if rev == nil {
+		return nil, ErrRevisionNotFound
+	}
+	return rev, nil
+}
+
+// DiffRevisions computes a line-level diff of the Markdown body between two revisions
+func (s *Service) DiffRevisions(articleID string, from, to int) ([]DiffLine, error) {
+	fromRev, err := s.GetRevision(articleID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRev, err := s.GetRevision(articleID, to)
+	if err != nil {
+		return nil, err
+	}
+	return DiffLines(fromRev.Body, toRev.Body)
+}
+
+// RestoreRevision makes a revision the current version of the article.
+// The restore itself is recorded as a new revision.
+func (s *Service) RestoreRevision(articleID string, number int, actor Actor) (*Article, error) {
+	rev, err := s.GetRevision(articleID, number)
+	if err != nil {
+		return nil, err
+	}
+	article, err := s.repo.FindByID(articleID)
+	if err != nil {
+		return nil, err
+	}
+	if article == nil {
+		return nil, ErrArticleNotFound
+	}
+
+	article.Title = rev.Title
+	article.Body = rev.Body
+	article.CategoryID = rev.CategoryID
+
+	if err := s.Update(articleID, article, actor); err != nil {
+		return nil, err
+	}
+	return article, nil
 }
 
-// Publish publishes an article
+// Publish publishes an article immediately, bypassing the review workflow.
+// Only the E2E test endpoint uses it; editors publish through Transition.
 func (s *Service) Publish(id string) error {
 	article, err := s.repo.FindByID(id)
 	if err != nil {
 		return err
 	}
 	if article == nil {
-		return errors.New("article not found")
+		return ErrArticleNotFound
+	}
+
+	if article.Status != StatusPublished {
+		if _, err := s.repo.TransitionStatus(id, article.Status, StatusPublished, "", nil); err != nil {
+			return err
+		}
+	}
+	s.embedArticle(id)
+	return nil
+}
+
+// Schedule sets the future publish and unpublish times of an article.
+// Passing nil for both clears the schedule.
+func (s *Service) Schedule(id string, publishAt, unpublishAt *time.Time) (*Article, error) {
+	article, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if article == nil {
+		return nil, ErrArticleNotFound
+	}
+
+	if err := validateSchedule(article, publishAt, unpublishAt, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetSchedule(id, publishAt, unpublishAt); err != nil {
+		return nil, err
+	}
+
+	article.PublishAt = publishAt
+	article.UnpublishAt = unpublishAt
+	return article, nil
+}
+
+// validateSchedule checks that scheduled times are in the future and consistent with the article status
+func validateSchedule(article *Article, publishAt, unpublishAt *time.Time, now time.Time) error {
+	if publishAt != nil {
+		if article.Status != StatusApproved {
+			return fmt.Errorf("%w: only approved articles can be scheduled for publishing", ErrInvalidSchedule)
+		}
+		if !publishAt.After(now) {
+			return fmt.Errorf("%w: publish_at must be in the future", ErrInvalidSchedule)
+		}
+	}
+	if unpublishAt != nil {
+		if publishAt == nil && article.Status != StatusPublished {
+			return fmt.Errorf("%w: unpublish_at requires a published article or a publish_at", ErrInvalidSchedule)
+		}
+		if !unpublishAt.After(now) {
+			return fmt.Errorf("%w: unpublish_at must be in the future", ErrInvalidSchedule)
+		}
+		if publishAt != nil && !unpublishAt.After(*publishAt) {
+			return fmt.Errorf("%w: unpublish_at must be after publish_at", ErrInvalidSchedule)
+		}
+	}
+	return nil
+}
+
+// Transition moves an article through the editorial workflow (submit, approve,
+// request-changes, publish, archive) and records who did it. A note given with
+// the transition is also stored as a private reviewer note.
+func (s *Service) Transition(actor Actor, id, name, note string) (*Article, error) {
+	article, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if article == nil {
+		return nil, ErrArticleNotFound
+	}
+
+	to, action, err := nextStatus(name, article.Status)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot %s an article in status %s", err, name, article.Status)
+	}
+	if !CanArticle(actor, article, action) {
+		return nil, ErrForbidden
+	}
+
+	var notePtr *string
+	if note != "" {
+		notePtr = &note
+	}
+	if _, err := s.repo.TransitionStatus(id, article.Status, to, actor.UserID, notePtr); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: article status changed concurrently", ErrInvalidTransition)
+		}
+		return nil, err
+	}
+
+	if note != "" {
+		if _, err := s.AddReviewNote(id, actor.UserID, note); err != nil {
+			return nil, err
+		}
+	}
+	if to == StatusPublished {
+		s.embedArticle(id)
+	}
+
+	return s.repo.FindByID(id)
+}
+
+// ListTransitions retrieves who moved an article between workflow states and when
+func (s *Service) ListTransitions(articleID string) ([]StatusTransition, error) {
+	return s.repo.ListTransitions(articleID)
+}
+
+// AddReviewNote adds a private reviewer note to an article
+func (s *Service) AddReviewNote(articleID, authorID, body string) (*ReviewNote, error) {
+	if strings.TrimSpace(body) == "" {
+		return nil, errors.New("note body cannot be empty")
+	}
+	note := &ReviewNote{
+		ArticleID: articleID,
+		AuthorID:  authorID,
+		Body:      body,
+	}
+	if err := s.repo.AddReviewNote(note); err != nil {
+		return nil, err
 	}
+	return note, nil
+}
 
-	article.Status = "PUBLISHED"
-	now := time.Now()
-	article.PublishedAt = &now
+// ListReviewNotes retrieves the private reviewer notes of an article
+func (s *Service) ListReviewNotes(articleID string) ([]ReviewNote, error) {
+	return s.repo.ListReviewNotes(articleID)
+}
 
-	return s.repo.Update(id, article)
+// AuthorizeArticle loads an article and checks that the actor may perform the action on it
+func (s *Service) AuthorizeArticle(actor Actor, id string, action Action) (*Article, error) {
+	article, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if article == nil {
+		return nil, ErrArticleNotFound
+	}
+	if !CanArticle(actor, article, action) {
+		return nil, ErrForbidden
+	}
+	return article, nil
+}
+
+// AuthorizeComment loads a comment and checks that the actor may edit or delete it
+func (s *Service) AuthorizeComment(actor Actor, commentID string) (*Comment, error) {
+	comment, err := s.repo.FindCommentByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil {
+		return nil, ErrCommentNotFound
+	}
+	if !CanComment(actor, comment) {
+		return nil, ErrForbidden
+	}
+	return comment, nil
 }
 
 // Delete soft deletes an article
@@ -180,6 +478,15 @@ func (s *Service) GetPublicArticles(categoryID string, tags []string, limit, off
 	return articles, count, nil
 }
 
+// GetPublishedBySlug retrieves a published article by slug, or nil if there is none
+func (s *Service) GetPublishedBySlug(slug string) (*Article, error) {
+	article, err := s.repo.FindBySlug(slug)
+	if err != nil || article == nil || article.Status != StatusPublished {
+		return nil, err
+	}
+	return article, nil
+}
+
 // GetCategoriesWithCounts retrieves all categories with their article counts
 func (s *Service) GetCategoriesWithCounts() ([]CategoryWithCount, error) {
 	return s.repo.GetCategoriesWithCounts()
@@ -190,23 +497,223 @@ func (s *Service) GetTagsWithCounts(popular bool, limit int) ([]TagWithCount, er
 	return s.repo.GetTagsWithCounts(popular, limit)
 }
 
-// AddComment adds a comment to an article
-func (s *Service) AddComment(articleID, userID, body string) (*Comment, error) {
-	if body == "" {
-		return nil, errors.New("comment body cannot be empty")
+// AddComment adds a comment to an article, or a reply when parentID is set.
+// Replies may be nested up to the configured MaxCommentDepth. The comment filters
+// decide whether it is published right away or waits for a moderator.
+func (s *Service) AddComment(articleID, userID, body string, parentID *string) (*Comment, error) {
+	if strings.TrimSpace(body) == "" {
+		return nil, ErrEmptyComment
 	}
 	comment := &Comment{
 		ArticleID: articleID,
 		UserID:    userID,
 		Body:      body,
 	}
-	err := s.repo.AddComment(comment)
+
+	if parentID != nil && *parentID != "" {
+		parent, err := s.repo.FindCommentByID(*parentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil || parent.ArticleID != articleID || parent.DeletedAt != nil || parent.Status != CommentApproved {
+			return nil, ErrCommentNotFound
+		}
+		if parent.Depth+1 > s.config.MaxCommentDepth {
+			return nil, ErrCommentTooDeep
+		}
+		comment.ParentID = &parent.ID
+		comment.Depth = parent.Depth + 1
+	}
+
+	verdict, err := s.moderate(userID, body, "")
+	if err != nil {
+		return nil, err
+	}
+	comment.Status = verdict.Status
+	if verdict.Reason != "" {
+		comment.ModerationReason = &verdict.Reason
+	}
+
+	err = s.repo.AddComment(comment)
 	return comment, err
 }
 
-// GetComments retrieves comments for an article
-func (s *Service) GetComments(articleID string) ([]Comment, error) {
-	return s.repo.GetComments(articleID)
+// moderate runs the comment filters on a body written by the user. editedID is the comment
+// being edited, so an edit is not taken for a repeat of itself; it is empty for a new comment.
+func (s *Service) moderate(userID, body, editedID string) (CommentVerdict, error) {
+	candidate := &CommentCandidate{Body: body, UserID: userID}
+	if len(s.filters) > 0 {
+		since := time.Now().Add(-s.config.Moderation.RepeatWindow)
+		approved, recent, err := s.repo.CommenterHistory(userID, since, editedID)
+		if err != nil {
+			return CommentVerdict{}, err
+		}
+		candidate.ApprovedCount = approved
+		candidate.RecentBodies = recent
+	}
+	return ModerateComment(s.filters, candidate), nil
+}
+
+// ListComments retrieves a page of an article's top-level comments with their reply counts
+func (s *Service) ListComments(articleID, cursor string, limit int) (*CommentPage, error) {
+	after, err := DecodeCommentCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	limit = clampCommentLimit(limit)
+
+	comments, err := s.repo.ListTopLevelComments(articleID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	return newCommentPage(comments, limit), nil
+}
+
+// ListReplies retrieves a page of direct replies to a comment of the article
+func (s *Service) ListReplies(articleID, commentID, cursor string, limit int) (*CommentPage, error) {
+	after, err := DecodeCommentCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	limit = clampCommentLimit(limit)
+
+	parent, err := s.repo.FindCommentByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil || parent.ArticleID != articleID {
+		return nil, ErrCommentNotFound
+	}
+
+	replies, err := s.repo.ListReplies(commentID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	return newCommentPage(replies, limit), nil
+}
+
+// UpdateComment changes the body of a comment of the article on behalf of its author or a moderator
+func (s *Service) UpdateComment(actor Actor, articleID, commentID, body string) (*Comment, error) {
+	if strings.TrimSpace(body) == "" {
+		return nil, ErrEmptyComment
+	}
+	comment, err := s.authorizeArticleComment(actor, articleID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Edits by the author go through the filters again; moderators' edits keep the status
+	var verdict *CommentVerdict
+	if !actor.IsModerator() {
+		v, err := s.moderate(comment.UserID, body, commentID)
+		if err != nil {
+			return nil, err
+		}
+		if v.Status != CommentApproved {
+			verdict = &v
+		}
+	}
+
+	updatedAt, err := s.repo.UpdateComment(commentID, body, actor.UserID, verdict)
+	if err == sql.ErrNoRows {
+		return nil, ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	comment.Body = body
+	comment.UpdatedAt = updatedAt
+	if verdict != nil {
+		comment.Status = verdict.Status
+		comment.ModerationReason = &verdict.Reason
+	}
+	return comment, nil
+}
+
+// DeleteComment soft deletes a comment of the article on behalf of its author or a moderator.
+// Replies remain visible under a placeholder.
+func (s *Service) DeleteComment(actor Actor, articleID, commentID string) error {
+	if _, err := s.authorizeArticleComment(actor, articleID, commentID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteComment(commentID, actor.UserID); err == sql.ErrNoRows {
+		return ErrCommentNotFound
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListCommentEdits retrieves the edit history of a comment; only moderators may see it
+func (s *Service) ListCommentEdits(actor Actor, articleID, commentID string) ([]CommentEdit, error) {
+	if !actor.IsModerator() {
+		return nil, ErrForbidden
+	}
+	comment, err := s.repo.FindCommentByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil || comment.ArticleID != articleID {
+		return nil, ErrCommentNotFound
+	}
+	return s.repo.ListCommentEdits(commentID)
+}
+
+// ListModerationQueue retrieves comments in a moderation status, PENDING by default; moderators only
+func (s *Service) ListModerationQueue(actor Actor, status string, limit, offset int) ([]Comment, int, error) {
+	if !actor.IsModerator() {
+		return nil, 0, ErrForbidden
+	}
+	if status == "" {
+		status = CommentPending
+	}
+	if !isCommentStatus(status) {
+		return nil, 0, ErrInvalidStatus
+	}
+	return s.repo.ListCommentsByStatus(status, clampCommentLimit(limit), offset)
+}
+
+// ModerateCommentAs records a moderator decision: APPROVED, REJECTED or SPAM
+func (s *Service) ModerateCommentAs(actor Actor, commentID, status string, reason *string) (*Comment, error) {
+	if !actor.IsModerator() {
+		return nil, ErrForbidden
+	}
+	if !isCommentStatus(status) || status == CommentPending {
+		return nil, ErrInvalidStatus
+	}
+	if err := s.repo.SetCommentStatus(commentID, status, actor.UserID, reason); err == sql.ErrNoRows {
+		return nil, ErrCommentNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	comment, err := s.repo.FindCommentByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil {
+		return nil, ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+func isCommentStatus(status string) bool {
+	switch status {
+	case CommentPending, CommentApproved, CommentRejected, CommentSpam:
+		return true
+	}
+	return false
+}
+
+// authorizeArticleComment checks that a live comment belongs to the article and the actor may change it
+func (s *Service) authorizeArticleComment(actor Actor, articleID, commentID string) (*Comment, error) {
+	comment, err := s.AuthorizeComment(actor, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.ArticleID != articleID || comment.DeletedAt != nil {
+		return nil, ErrCommentNotFound
+	}
+	return comment, nil
 }
 
 // AddLike adds or updates a like/dislike
@@ -234,29 +741,107 @@ func (s *Service) GetUserLike(articleID, userID string) (*bool, error) {
 	return s.repo.GetUserLike(articleID, userID)
 }
 
-// Search performs full-text search on published articles
-func (s *Service) Search(query string, categoryID string, tags []string, limit, offset int) ([]SearchResult, int, error) {
-	if len(query) < 2 {
-		return []SearchResult{}, 0, fmt.Errorf("query too short")
+// GetUserReactions returns the user's reaction (ReactionLike or ReactionDislike) to each of
+// the articles the user has reacted to, keyed by article ID
+func (s *Service) GetUserReactions(articleIDs []string, userID string) (map[string]string, error) {
+	reactions := map[string]string{}
+	if userID == "" || len(articleIDs) == 0 {
+		return reactions, nil
+	}
+	likes, err := s.repo.GetUserLikes(articleIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	for id, isLike := range likes {
+		if isLike {
+			reactions[id] = ReactionLike
+		} else {
+			reactions[id] = ReactionDislike
+		}
 	}
+	return reactions, nil
+}
 
-	results, total, err := s.repo.Search(query, categoryID, tags, limit, offset)
-	return results, total, err
+// Search searches published articles by keyword, by meaning or both, as the mode asks
+func (s *Service) Search(ctx context.Context, opts SearchOptions) (*SearchPage, error) {
+	if len(opts.Query) < 2 {
+		return nil, ErrQueryTooShort
+	}
+	if opts.Language != "" && !validLanguage(opts.Language) {
+		return nil, ErrInvalidLanguage
+	}
+	q := parseSearchQuery(opts.Query)
+	for _, id := range opts.CategoryIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, ErrInvalidSearchFilter
+		}
+	}
+	if !opts.PublishedFrom.IsZero() && !opts.PublishedTo.IsZero() && opts.PublishedTo.Before(opts.PublishedFrom) {
+		return nil, ErrInvalidSearchFilter
+	}
+
+	switch opts.Mode {
+	case "", SearchModeKeyword:
+		opts.Mode = SearchModeKeyword
+	case SearchModeSemantic, SearchModeHybrid:
+		if s.embedder == nil {
+			return nil, ErrSemanticSearchUnavailable
+		}
+		// A query of qualifiers only has no meaning to embed and is matched by keyword
+		if q.Semantic == "" {
+			opts.Mode = SearchModeKeyword
+			break
+		}
+		vectors, err := s.embedder.Embed(ctx, []string{q.Semantic})
+		if err != nil {
+			return nil, err
+		}
+		opts.Embedding = vectors[0]
+		opts.EmbeddingModel = s.embedder.Model()
+		opts.MinSimilarity = s.config.Embeddings.MinSimilarity
+	default:
+		return nil, ErrInvalidSearchMode
+	}
+
+	return s.repo.Search(opts)
+}
+
+// generateSlug creates a URL-friendly slug from a title, transliterating Russian and Kazakh.
+// A title with nothing to keep falls back to a slug built from the article ID.
+func generateSlug(title, id string) string {
+	if s := slugify.Make(title); s != "" {
+		return s
+	}
+	return "article-" + strings.SplitN(id, "-", 2)[0]
 }
 
-// generateSlug creates a URL-friendly slug from a string
-func generateSlug(s string) string {
-	s = strings.ToLower(s)
-	// Remove invalid characters
-	reg := regexp.MustCompile("[^a-z0-9]+")
-	s = reg.ReplaceAllString(s, "-")
-	// Trim dashes
-	s = strings.Trim(s, "-")
-	return s
+// Preview creates the plain text preview shown in public listings
+func Preview(markdown string) string {
+	return generatePreview(markdown)
 }
 
 // generatePreview creates a plain text preview from markdown
 func generatePreview(markdown string) string {
+	text := plainText(markdown)
+	lines := strings.Split(text, "\n")
+
+	// Take first 10 lines
+	if len(lines) > 10 {
+		lines = lines[:10]
+		text = strings.Join(lines, "\n") + "..."
+	} else {
+		text = strings.Join(lines, "\n")
+	}
+
+	// Hard limit characters
+	if len(text) > 300 {
+		text = text[:300] + "..."
+	}
+	return text
+}
+
+// plainText strips markdown formatting, images and code from an article body
+func plainText(markdown string) string {
 	// Remove headers
 	re := regexp.MustCompile(`(?m)^#{1,6}\s+`)
 	text := re.ReplaceAllString(markdown, "")
@@ -282,21 +867,5 @@ func generatePreview(markdown string) string {
 	text = re.ReplaceAllString(text, "")
 
 	// Normalize whitespace
-	text = strings.TrimSpace(text)
-	lines := strings.Split(text, "\n")
-
-	// Take first 10 lines
-	if len(lines) > 10 {
-		lines = lines[:10]
-		text = strings.Join(lines, "\n") + "..."
-	} else {
-		text = strings.Join(lines, "\n")
-	}
-
-	// Hard limit characters
-	if len(text) > 300 {
-		text = text[:300] + "..."
-	}
-
-	return text
+	return strings.TrimSpace(text)
 }
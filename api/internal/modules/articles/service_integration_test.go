@@ -1,7 +1,9 @@
 package articles
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/ai-dala/api/internal/testutil"
 	"github.com/jmoiron/sqlx"
@@ -15,7 +17,7 @@ func TestService_Create_DuplicateSlug(t *testing.T) {
 	db := sqlx.NewDb(testDB.DB, "postgres")
 
 	repo := NewRepository(db)
-	service := NewService(repo)
+	service := NewService(repo, DefaultConfig())
 
 	// Create first article
 	article1 := &Article{
@@ -61,7 +63,7 @@ func TestService_Update_DuplicateSlug(t *testing.T) {
 	db := sqlx.NewDb(testDB.DB, "postgres")
 
 	repo := NewRepository(db)
-	service := NewService(repo)
+	service := NewService(repo, DefaultConfig())
 
 	// Create first article
 	article1 := &Article{
@@ -88,7 +90,7 @@ func TestService_Update_DuplicateSlug(t *testing.T) {
 	// Update second article to have same title as first
 	article2.Title = "Original Title"
 	article2.Slug = "" // Force regeneration
-	err = service.Update(article2.ID, article2)
+	err = service.Update(article2.ID, article2, Actor{UserID: article2.AuthorID})
 	require.NoError(t, err)
 
 	// Should have suffix
@@ -98,7 +100,7 @@ func TestService_Update_DuplicateSlug(t *testing.T) {
 
 	// Update first article with SAME title (no change)
 	article1.Slug = "" // Force regeneration check
-	err = service.Update(article1.ID, article1)
+	err = service.Update(article1.ID, article1, Actor{UserID: article1.AuthorID})
 	require.NoError(t, err)
 
 	// Should keep original slug (no suffix added to itself)
@@ -106,3 +108,474 @@ func TestService_Update_DuplicateSlug(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "original-title", updatedArticle1.Slug)
 }
+
+func TestService_CommentThreads(t *testing.T) {
+	testDB := testutil.SetupTestDatabase(t)
+	db := sqlx.NewDb(testDB.DB, "postgres")
+
+	repo := NewRepository(db)
+	service := NewService(repo, Config{MaxCommentDepth: 1})
+
+	userID := "00000000-0000-0000-0000-000000000001"
+	article := &Article{Title: "Threads", Body: "Content", AuthorID: userID, Status: "DRAFT"}
+	require.NoError(t, service.Create(article))
+
+	var top []*Comment
+	for i := 0; i < 3; i++ {
+		c, err := service.AddComment(article.ID, userID, "comment", nil)
+		require.NoError(t, err)
+		top = append(top, c)
+	}
+
+	reply, err := service.AddComment(article.ID, userID, "reply", &top[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reply.Depth)
+
+	t.Run("Depth limit", func(t *testing.T) {
+		_, err := service.AddComment(article.ID, userID, "too deep", &reply.ID)
+		assert.ErrorIs(t, err, ErrCommentTooDeep)
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		first, err := service.ListComments(article.ID, "", 2)
+		require.NoError(t, err)
+		require.Len(t, first.Comments, 2)
+		assert.True(t, first.HasMore)
+
+		second, err := service.ListComments(article.ID, first.NextCursor, 2)
+		require.NoError(t, err)
+		require.Len(t, second.Comments, 1)
+		assert.False(t, second.HasMore)
+		assert.Equal(t, top[0].ID, second.Comments[0].ID)
+		assert.Equal(t, 1, second.Comments[0].ReplyCount)
+	})
+
+	t.Run("Replies", func(t *testing.T) {
+		page, err := service.ListReplies(article.ID, top[0].ID, "", 0)
+		require.NoError(t, err)
+		require.Len(t, page.Comments, 1)
+		assert.Equal(t, reply.ID, page.Comments[0].ID)
+	})
+}
+
+func TestService_CommentEditAndDelete(t *testing.T) {
+	testDB := testutil.SetupTestDatabase(t)
+	db := sqlx.NewDb(testDB.DB, "postgres")
+
+	repo := NewRepository(db)
+	service := NewService(repo, Config{MaxCommentDepth: 5})
+
+	author := Actor{UserID: "00000000-0000-0000-0000-000000000001"}
+	other := Actor{UserID: "00000000-0000-0000-0000-000000000002"}
+	moderator := Actor{UserID: "00000000-0000-0000-0000-000000000003", CanManage: true}
+
+	article := &Article{Title: "Edits", Body: "Content", AuthorID: author.UserID, Status: "DRAFT"}
+	require.NoError(t, service.Create(article))
+
+	comment, err := service.AddComment(article.ID, author.UserID, "first", nil)
+	require.NoError(t, err)
+	reply, err := service.AddComment(article.ID, other.UserID, "reply", &comment.ID)
+	require.NoError(t, err)
+
+	t.Run("Only author or moderator may edit", func(t *testing.T) {
+		_, err := service.UpdateComment(other, article.ID, comment.ID, "hijacked")
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		updated, err := service.UpdateComment(author, article.ID, comment.ID, "second")
+		require.NoError(t, err)
+		assert.Equal(t, "second", updated.Body)
+	})
+
+	t.Run("Edit history is for moderators", func(t *testing.T) {
+		_, err := service.ListCommentEdits(author, article.ID, comment.ID)
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		edits, err := service.ListCommentEdits(moderator, article.ID, comment.ID)
+		require.NoError(t, err)
+		require.Len(t, edits, 1)
+		assert.Equal(t, "first", edits[0].Body)
+	})
+
+	t.Run("Soft delete keeps replies", func(t *testing.T) {
+		require.NoError(t, service.DeleteComment(moderator, article.ID, comment.ID))
+		assert.ErrorIs(t, service.DeleteComment(author, article.ID, comment.ID), ErrCommentNotFound)
+
+		page, err := service.ListComments(article.ID, "", 0)
+		require.NoError(t, err)
+		require.Len(t, page.Comments, 1)
+		assert.Equal(t, DeletedCommentBody, page.Comments[0].Body)
+		assert.Equal(t, 1, page.Comments[0].ReplyCount)
+
+		replies, err := service.ListReplies(article.ID, comment.ID, "", 0)
+		require.NoError(t, err)
+		require.Len(t, replies.Comments, 1)
+		assert.Equal(t, reply.ID, replies.Comments[0].ID)
+	})
+}
+
+func TestService_CommentModeration(t *testing.T) {
+	testDB := testutil.SetupTestDatabase(t)
+	db := sqlx.NewDb(testDB.DB, "postgres")
+
+	repo := NewRepository(db)
+	service := NewService(repo, DefaultConfig())
+
+	userID := "00000000-0000-0000-0000-000000000001"
+	moderator := Actor{UserID: "00000000-0000-0000-0000-000000000003", CanManage: true}
+
+	article := &Article{Title: "Moderation", Body: "Content", AuthorID: userID, Status: "DRAFT"}
+	require.NoError(t, service.Create(article))
+
+	first, err := service.AddComment(article.ID, userID, "Hello there", nil)
+	require.NoError(t, err)
+	assert.Equal(t, CommentPending, first.Status)
+
+	t.Run("Held comments are not public", func(t *testing.T) {
+		page, err := service.ListComments(article.ID, "", 0)
+		require.NoError(t, err)
+		assert.Empty(t, page.Comments)
+	})
+
+	t.Run("Queue is for moderators", func(t *testing.T) {
+		_, _, err := service.ListModerationQueue(Actor{UserID: userID}, "", 0, 0)
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		queue, total, err := service.ListModerationQueue(moderator, "", 0, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, first.ID, queue[0].ID)
+	})
+
+	t.Run("Approved commenter skips review", func(t *testing.T) {
+		approved, err := service.ModerateCommentAs(moderator, first.ID, CommentApproved, nil)
+		require.NoError(t, err)
+		assert.Equal(t, CommentApproved, approved.Status)
+
+		second, err := service.AddComment(article.ID, userID, "Another thought", nil)
+		require.NoError(t, err)
+		assert.Equal(t, CommentApproved, second.Status)
+
+		page, err := service.ListComments(article.ID, "", 0)
+		require.NoError(t, err)
+		assert.Len(t, page.Comments, 2)
+	})
+
+	t.Run("Repeats are spam", func(t *testing.T) {
+		repeat, err := service.AddComment(article.ID, userID, "another thought!", nil)
+		require.NoError(t, err)
+		assert.Equal(t, CommentSpam, repeat.Status)
+	})
+
+	t.Run("Editing punctuation is not a repeat", func(t *testing.T) {
+		comment, err := service.AddComment(article.ID, userID, "Fresh idea here", nil)
+		require.NoError(t, err)
+		require.Equal(t, CommentApproved, comment.Status)
+
+		edited, err := service.UpdateComment(Actor{UserID: userID}, article.ID, comment.ID, "Fresh idea here.")
+		require.NoError(t, err)
+		assert.Equal(t, CommentApproved, edited.Status)
+	})
+}
+
+func TestCommentReports(t *testing.T) {
+	testDB := testutil.SetupTestDatabase(t)
+	db := sqlx.NewDb(testDB.DB, "postgres")
+
+	service := NewService(NewRepository(db), Config{MaxCommentDepth: 5})
+	targets := NewCommentReports(service)
+
+	userID := "00000000-0000-0000-0000-000000000001"
+	article := &Article{Title: "Reported", Body: "Content", AuthorID: userID, Status: "DRAFT"}
+	require.NoError(t, service.Create(article))
+	comment, err := service.AddComment(article.ID, userID, "rude", nil)
+	require.NoError(t, err)
+
+	exists, err := targets.Exists(comment.ID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, targets.Hide(comment.ID, "", "hidden after 5 reports"))
+	exists, err = targets.Exists(comment.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, targets.Restore(comment.ID, userID))
+	restored, err := service.repo.FindCommentByID(comment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, CommentApproved, restored.Status)
+
+	// Dismissing reports does not undo a moderator's rejection
+	moderator := Actor{UserID: "00000000-0000-0000-0000-000000000003", CanManage: true}
+	_, err = service.ModerateCommentAs(moderator, comment.ID, CommentRejected, nil)
+	require.NoError(t, err)
+	require.NoError(t, targets.Restore(comment.ID, userID))
+	rejected, err := service.repo.FindCommentByID(comment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, CommentRejected, rejected.Status)
+}
+
+func TestArticleReports(t *testing.T) {
+	testDB := testutil.SetupTestDatabase(t)
+	db := sqlx.NewDb(testDB.DB, "postgres")
+
+	service := NewService(NewRepository(db), DefaultConfig())
+	targets := NewArticleReports(service)
+	editorID := "00000000-0000-0000-0000-000000000003"
+
+	reported := &Article{Title: "Reported", Body: "Content", AuthorID: editorID, Status: StatusPublished}
+	archived := &Article{Title: "Retired", Body: "Content", AuthorID: editorID, Status: StatusPublished}
+	for _, a := range []*Article{reported, archived} {
+		require.NoError(t, service.Create(a))
+	}
+
+	require.NoError(t, targets.Hide(reported.ID, "", "hidden after 5 reports"))
+	_, err := service.repo.TransitionStatus(archived.ID, StatusPublished, StatusArchived, editorID, nil)
+	require.NoError(t, err)
+
+	// Dismissing reports republishes only what the reports hid
+	for _, a := range []*Article{reported, archived} {
+		require.NoError(t, targets.Restore(a.ID, editorID))
+	}
+	found, err := service.FindByID(reported.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusPublished, found.Status)
+	found, err = service.FindByID(archived.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusArchived, found.Status)
+
+	// Once republished, a later archive by an editor is not undone either
+	_, err = service.repo.TransitionStatus(reported.ID, StatusPublished, StatusArchived, editorID, nil)
+	require.NoError(t, err)
+	require.NoError(t, targets.Restore(reported.ID, editorID))
+	found, err = service.FindByID(reported.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusArchived, found.Status)
+}
+
+func TestService_Bookmarks(t *testing.T) {
+	testDB := testutil.SetupTestDatabase(t)
+	db := sqlx.NewDb(testDB.DB, "postgres")
+
+	repo := NewRepository(db)
+	service := NewService(repo, DefaultConfig())
+
+	readerID := "00000000-0000-0000-0000-000000000001"
+	otherID := "00000000-0000-0000-0000-000000000002"
+	published := &Article{Title: "Saved", Slug: "saved", Body: "## Later\n**Worth** reading", AuthorID: otherID, Status: "PUBLISHED"}
+	require.NoError(t, repo.Create(published))
+	draft := &Article{Title: "Draft", Slug: "draft", Body: "Content", AuthorID: otherID, Status: "DRAFT"}
+	require.NoError(t, repo.Create(draft))
+
+	t.Run("Only published articles", func(t *testing.T) {
+		err := service.AddBookmark(readerID, draft.ID, nil)
+		assert.ErrorIs(t, err, ErrArticleNotFound)
+	})
+
+	t.Run("Reading list", func(t *testing.T) {
+		require.NoError(t, service.AddBookmark(readerID, published.ID, nil))
+		require.NoError(t, service.AddBookmark(readerID, published.ID, nil))
+
+		bookmarks, total, err := service.ListBookmarks(readerID, "", 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, bookmarks, 1)
+		assert.Equal(t, published.ID, bookmarks[0].ID)
+		assert.Equal(t, "Later\nWorth reading", bookmarks[0].Body)
+		assert.Nil(t, bookmarks[0].CollectionID)
+	})
+
+	t.Run("Collections are private", func(t *testing.T) {
+		collection, err := service.CreateCollection(readerID, " Weekend ")
+		require.NoError(t, err)
+		assert.Equal(t, "Weekend", collection.Name)
+
+		_, err = service.CreateCollection(readerID, "Weekend")
+		assert.ErrorIs(t, err, ErrDuplicateCollection)
+
+		err = service.AddBookmark(otherID, published.ID, &collection.ID)
+		assert.ErrorIs(t, err, ErrCollectionNotFound)
+
+		require.NoError(t, service.AddBookmark(readerID, published.ID, &collection.ID))
+		bookmarks, total, err := service.ListBookmarks(readerID, collection.ID, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, bookmarks, 1)
+
+		require.NoError(t, service.DeleteCollection(readerID, collection.ID))
+		bookmarks, _, err = service.ListBookmarks(readerID, "", 20, 0)
+		require.NoError(t, err)
+		require.Len(t, bookmarks, 1)
+		assert.Nil(t, bookmarks[0].CollectionID)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		require.NoError(t, service.RemoveBookmark(readerID, published.ID))
+		_, total, err := service.ListBookmarks(readerID, "", 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+	})
+}
+
+func TestService_Related(t *testing.T) {
+	testDB := testutil.SetupTestDatabase(t)
+	db := sqlx.NewDb(testDB.DB, "postgres")
+
+	repo := NewRepository(db)
+	service := NewService(repo, DefaultConfig())
+	authorID := "00000000-0000-0000-0000-000000000001"
+
+	var tagID string
+	require.NoError(t, db.Get(&tagID, `INSERT INTO tags (code, name) VALUES ('agents', '{"en": "Agents"}') RETURNING id`))
+
+	source := &Article{Title: "Building agents", Slug: "building-agents", Body: "Agents plan and call tools.", AuthorID: authorID, Status: "PUBLISHED"}
+	tagged := &Article{Title: "Agent memory", Slug: "agent-memory", Body: "Memory for long running work.", AuthorID: authorID, Status: "PUBLISHED"}
+	similar := &Article{Title: "Tools", Slug: "tools", Body: "Agents call tools to plan.", AuthorID: authorID, Status: "PUBLISHED"}
+	unrelated := &Article{Title: "Gardening", Slug: "gardening", Body: "Tomatoes need sun.", AuthorID: authorID, Status: "PUBLISHED"}
+	draft := &Article{Title: "Draft agents", Slug: "draft-agents", Body: "Agents plan and call tools.", AuthorID: authorID, Status: "DRAFT"}
+	for _, a := range []*Article{source, tagged, similar, unrelated, draft} {
+		require.NoError(t, repo.Create(a))
+	}
+	require.NoError(t, repo.AddTags(source.ID, []string{tagID}))
+	require.NoError(t, repo.AddTags(tagged.ID, []string{tagID}))
+
+	related, err := service.Related(source.ID, 10)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, a := range related {
+		ids = append(ids, a.ID)
+	}
+	require.Len(t, ids, 2)
+	assert.Equal(t, tagged.ID, ids[0])
+	assert.Equal(t, similar.ID, ids[1])
+	assert.Equal(t, []string{"agents"}, related[0].Tags)
+
+	_, err = service.Related(draft.ID, 10)
+	assert.ErrorIs(t, err, ErrArticleNotFound)
+}
+
+func TestService_SemanticSearch(t *testing.T) {
+	testDB := testutil.SetupTestDatabase(t)
+	db := sqlx.NewDb(testDB.DB, "postgres")
+
+	repo := NewRepository(db)
+	cfg := DefaultConfig()
+	cfg.Embeddings.Provider = EmbeddingProviderLocal
+	service := NewService(repo, cfg)
+	ctx := context.Background()
+	authorID := "00000000-0000-0000-0000-000000000001"
+
+	quantum := &Article{Title: "Quantum annealing", Body: "Qubits settle into low energy states.", AuthorID: authorID, Status: "PUBLISHED"}
+	garden := &Article{Title: "Gardening", Body: "Tomatoes need sun and water.", AuthorID: authorID, Status: "PUBLISHED"}
+	for _, a := range []*Article{quantum, garden} {
+		require.NoError(t, service.Create(a))
+	}
+
+	// Saving embeds the article in the background, so nothing is left for the indexer
+	service.embedding.Wait()
+	chunks, err := repo.EmbeddedChunks(quantum.ID, service.embedder.Model())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Quantum annealing\n\nQubits settle into low energy states."}, chunks)
+	indexed, err := NewEmbeddingIndexer(service, 0).RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, indexed)
+
+	page, err := service.Search(ctx, SearchOptions{Query: "qubits quantum", Mode: SearchModeSemantic, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, SearchModeSemantic, page.Mode)
+	require.Len(t, page.Results, 1)
+	assert.Equal(t, quantum.ID, page.Results[0].ID)
+
+	page, err = service.Search(ctx, SearchOptions{Query: "qubits -annealing", Mode: SearchModeSemantic, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, page.Results)
+
+	page, err = service.Search(ctx, SearchOptions{Query: "tomatoes sun qubits energy", Mode: SearchModeHybrid, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.Total)
+
+	// Changed content is embedded again
+	garden.Body = "Qubits grow in quantum gardens."
+	require.NoError(t, service.Update(garden.ID, garden, Actor{UserID: authorID, CanManage: true}))
+	service.embedding.Wait()
+	page, err = service.Search(ctx, SearchOptions{Query: "qubits quantum", Mode: SearchModeSemantic, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.Total)
+
+	// An article with nothing to embed is not picked up again by every pass
+	empty := &Article{AuthorID: authorID, Status: "PUBLISHED"}
+	require.NoError(t, service.Create(empty))
+	service.embedding.Wait()
+	pending, err := repo.ListUnembedded(service.embedder.Model(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// An embedding of an older save that finishes late neither replaces the newer chunks
+	// nor marks the article as up to date
+	stale, err := repo.FindByID(garden.ID)
+	require.NoError(t, err)
+	garden.Body = "Tomatoes are back in the garden."
+	require.NoError(t, service.Update(garden.ID, garden, Actor{UserID: authorID, CanManage: true}))
+	service.embedding.Wait()
+	model := service.embedder.Model()
+	fresh, err := repo.EmbeddedChunks(garden.ID, model)
+	require.NoError(t, err)
+	vectors, err := service.embedder.Embed(ctx, []string{"Gardening\n\nQubits grow in quantum gardens."})
+	require.NoError(t, err)
+	require.NoError(t, repo.ReplaceEmbeddings(ctx, garden.ID, model, stale.UpdatedAt, []string{"Gardening\n\nQubits grow in quantum gardens."}, vectors))
+	require.NoError(t, repo.TouchEmbeddings(ctx, garden.ID, model, stale.UpdatedAt))
+	chunks, err = repo.EmbeddedChunks(garden.ID, model)
+	require.NoError(t, err)
+	assert.Equal(t, fresh, chunks)
+	pending, err = repo.ListUnembedded(model, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestService_SearchAnalytics(t *testing.T) {
+	testDB := testutil.SetupTestDatabase(t)
+	db := sqlx.NewDb(testDB.DB, "postgres")
+
+	repo := NewRepository(db)
+	service := NewService(repo, DefaultConfig())
+	ctx := context.Background()
+	article := &Article{Title: "Prompt engineering", Body: "Writing prompts.", AuthorID: "00000000-0000-0000-0000-000000000001", Status: "PUBLISHED"}
+	require.NoError(t, service.Create(article))
+
+	// A repeated search by the same reader is logged once
+	first, err := service.LogSearch("Prompt  Engineering", 1, SearchModeKeyword, "anon:a")
+	require.NoError(t, err)
+	repeated, err := service.LogSearch("prompt engineering", 1, SearchModeKeyword, "anon:a")
+	require.NoError(t, err)
+	assert.Equal(t, first, repeated)
+	other, err := service.LogSearch("prompt engineering", 1, SearchModeKeyword, "anon:b")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, other)
+	_, err = service.LogSearch("qubitz", 0, SearchModeKeyword, "anon:a")
+	require.NoError(t, err)
+
+	require.NoError(t, service.RecordSearchClick(first, article.ID, 0))
+	require.NoError(t, service.RecordSearchClick(first, article.ID, 0))
+	assert.ErrorIs(t, service.RecordSearchClick("00000000-0000-0000-0000-000000000099", article.ID, 0), ErrSearchNotFound)
+
+	today := utcDay(time.Now())
+	require.NoError(t, repo.RollupSearchStats(ctx, today.AddDate(0, 0, -1)))
+	// Rolling up again gives the same stats
+	require.NoError(t, repo.RollupSearchStats(ctx, today.AddDate(0, 0, -1)))
+
+	editor := Actor{UserID: "editor", CanManage: true}
+	top, err := service.SearchAnalytics(editor, SearchReportTopQueries, "", "", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, top.Queries, 2)
+	assert.Equal(t, SearchQueryStats{Query: "prompt engineering", Searches: 2, ClickedSearches: 1, Clicks: 1, CTR: 0.5}, top.Queries[0])
+
+	zero, err := service.SearchAnalytics(editor, SearchReportZeroResults, "", "", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, zero.Queries, 1)
+	assert.Equal(t, "qubitz", zero.Queries[0].Query)
+
+	ctr, err := service.SearchAnalytics(editor, SearchReportClickThrough, "", "", 2, 0)
+	require.NoError(t, err)
+	require.Len(t, ctr.Queries, 1)
+	assert.Equal(t, 0.5, ctr.Queries[0].CTR)
+}
@@ -0,0 +1,78 @@
+package articles
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSchedule(t *testing.T) {
+	now := time.Date(2025, 11, 20, 10, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	soon := now.Add(time.Hour)
+	later := now.Add(48 * time.Hour)
+
+	draft := &Article{Status: StatusDraft}
+	approved := &Article{Status: StatusApproved}
+	published := &Article{Status: StatusPublished}
+
+	tests := []struct {
+		name        string
+		article     *Article
+		publishAt   *time.Time
+		unpublishAt *time.Time
+		valid       bool
+	}{
+		{"Clear schedule", draft, nil, nil, true},
+		{"Publish approved later", approved, &soon, nil, true},
+		{"Publish and unpublish approved", approved, &soon, &later, true},
+		{"Publish unreviewed draft", draft, &soon, nil, false},
+		{"Publish in the past", approved, &past, nil, false},
+		{"Publish already published", published, &soon, nil, false},
+		{"Unpublish published later", published, nil, &later, true},
+		{"Unpublish draft without publish", draft, nil, &later, false},
+		{"Unpublish before publish", approved, &later, &soon, false},
+		{"Unpublish in the past", published, nil, &past, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSchedule(tt.article, tt.publishAt, tt.unpublishAt, now)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, ErrInvalidSchedule), "expected ErrInvalidSchedule, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSearchMatch(t *testing.T) {
+	all := searchMatch("", articleVector, 2)
+	for _, config := range []string{"english", "russian", "kazakh"} {
+		assert.Contains(t, all, "a.search_vector @@ websearch_to_tsquery('"+config+"', $2)")
+	}
+
+	ru := searchMatch("ru", titleVector, 3)
+	assert.Contains(t, ru, "ts_filter(a.search_vector, '{a}') @@ websearch_to_tsquery('russian', $3)")
+	assert.Contains(t, ru, "a.language = 'ru'")
+	assert.NotContains(t, ru, "english")
+}
+
+func TestService_Search_Language(t *testing.T) {
+	service := &Service{}
+	_, err := service.Search(context.Background(), SearchOptions{Query: "agents", Language: "de"})
+	assert.ErrorIs(t, err, ErrInvalidLanguage)
+	assert.True(t, validLanguage("kk"))
+	assert.False(t, validLanguage(""))
+}
+
+func TestGenerateSlug(t *testing.T) {
+	id := "1a2b3c4d-e89b-12d3-a456-426614174000"
+	assert.Equal(t, "machine-learning-basics", generateSlug("Machine Learning: Basics", id))
+	assert.Equal(t, "mashinnoe-obuchenie", generateSlug("Машинное обучение", id))
+	assert.Equal(t, "article-1a2b3c4d", generateSlug("!!!", id))
+}
@@ -0,0 +1,243 @@
+package articles
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidStatsRange = errors.New("invalid date range")
+
+// maxStatsRangeDays bounds the date range of a stats request
+const maxStatsRangeDays = 366
+
+// statsDateLayout is the format of stats dates in requests and responses
+const statsDateLayout = "2006-01-02"
+
+// DailyStats are the activity counters of an article for one UTC day
+type DailyStats struct {
+	Date     string `db:"day" json:"date"`
+	Views    int    `db:"views" json:"views"`
+	Likes    int    `db:"likes" json:"likes"`
+	Comments int    `db:"comments" json:"comments"`
+}
+
+// ArticleStats summarises the activity of an article over a date range.
+// Views count unique visitors per day.
+type ArticleStats struct {
+	ArticleID string       `json:"article_id"`
+	From      string       `json:"from"`
+	To        string       `json:"to"`
+	Views     int          `json:"views"`
+	Likes     int          `json:"likes"`
+	Comments  int          `json:"comments"`
+	Days      []DailyStats `json:"days"`
+}
+
+// ViewSalt returns the visitor hashing salt of a day, storing the candidate if the day has none yet
+func (r *Repository) ViewSalt(day time.Time, candidate []byte) ([]byte, error) {
+	query := `
+		WITH inserted AS (
+			INSERT INTO article_view_salts (day, salt) VALUES ($1, $2)
+			ON CONFLICT (day) DO NOTHING
+			RETURNING salt
+		)
+		SELECT salt FROM inserted
+		UNION ALL
+		SELECT salt FROM article_view_salts WHERE day = $1
+		LIMIT 1
+	`
+	var salt []byte
+	err := r.db.Get(&salt, query, day, candidate)
+	return salt, err
+}
+
+// RecordView counts a visitor once per day on a published article
+func (r *Repository) RecordView(articleID string, day time.Time, visitorHash string) error {
+	query := `
+		INSERT INTO article_views (article_id, day, visitor_hash)
+		SELECT id, $2, $3 FROM articles
+		WHERE id = $1 AND status = 'PUBLISHED' AND deleted_at IS NULL
+		ON CONFLICT DO NOTHING
+	`
+	_, err := r.db.Exec(query, articleID, day, visitorHash)
+	return err
+}
+
+// RollupStats recomputes the daily stats of every day since the given one, then drops
+// the raw views before it and the salts of days before today
+func (r *Repository) RollupStats(ctx context.Context, since, today time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Reset first so days whose likes or comments were since removed drop back to zero
+	reset := `UPDATE article_daily_stats SET views = 0, likes = 0, comments = 0, updated_at = NOW() WHERE day >= $1`
+	if _, err := tx.ExecContext(ctx, reset, since); err != nil {
+		return err
+	}
+
+	rollup := `
+		INSERT INTO article_daily_stats (article_id, day, views, likes, comments)
+		SELECT article_id, day, SUM(views), SUM(likes), SUM(comments)
+		FROM (
+			SELECT article_id, day, COUNT(*) AS views, 0 AS likes, 0 AS comments
+			FROM article_views
+			WHERE day >= $1
+			GROUP BY 1, 2
+			UNION ALL
+			SELECT article_id, (created_at AT TIME ZONE 'UTC')::date, 0, COUNT(*), 0
+			FROM article_likes
+			WHERE is_like AND (created_at AT TIME ZONE 'UTC')::date >= $1
+			GROUP BY 1, 2
+			UNION ALL
+			SELECT article_id, (created_at AT TIME ZONE 'UTC')::date, 0, 0, COUNT(*)
+			FROM comments
+			WHERE status = 'APPROVED' AND deleted_at IS NULL AND (created_at AT TIME ZONE 'UTC')::date >= $1
+			GROUP BY 1, 2
+		) activity
+		GROUP BY article_id, day
+		ON CONFLICT (article_id, day) DO UPDATE
+		SET views = EXCLUDED.views, likes = EXCLUDED.likes, comments = EXCLUDED.comments, updated_at = NOW()
+	`
+	if _, err := tx.ExecContext(ctx, rollup, since); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM article_views WHERE day < $1`, since); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM article_view_salts WHERE day < $1`, today); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListDailyStats retrieves the daily stats of an article, with a zero row for days without activity
+func (r *Repository) ListDailyStats(articleID string, from, to time.Time) ([]DailyStats, error) {
+	query := `
+		SELECT to_char(d.day, 'YYYY-MM-DD') AS day,
+			COALESCE(s.views, 0) AS views, COALESCE(s.likes, 0) AS likes, COALESCE(s.comments, 0) AS comments
+		FROM generate_series($2::date, $3::date, INTERVAL '1 day') AS d(day)
+		LEFT JOIN article_daily_stats s ON s.article_id = $1 AND s.day = d.day::date
+		ORDER BY d.day
+	`
+	days := []DailyStats{}
+	err := r.db.Select(&days, query, articleID, from, to)
+	return days, err
+}
+
+// viewSalts caches the salt of the current day so recording a view costs one query
+type viewSalts struct {
+	mu   sync.Mutex
+	day  time.Time
+	salt []byte
+}
+
+// get returns the salt of the day, agreeing on it with other replicas through the database
+func (c *viewSalts) get(repo *Repository, day time.Time) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.day.Equal(day) {
+		return c.salt, nil
+	}
+
+	candidate := make([]byte, 32)
+	if _, err := rand.Read(candidate); err != nil {
+		return nil, err
+	}
+	salt, err := repo.ViewSalt(day, candidate)
+	if err != nil {
+		return nil, err
+	}
+	c.day, c.salt = day, salt
+	return salt, nil
+}
+
+// visitorHash identifies a visitor within one day without keeping the visitor key
+func visitorHash(salt []byte, visitor string) string {
+	sum := sha256.Sum256(append(append([]byte{}, salt...), visitor...))
+	return hex.EncodeToString(sum[:])
+}
+
+// utcDay truncates a time to the start of its UTC day
+func utcDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// parseStatsRange resolves the requested range, defaulting to the 30 days up to today
+func parseStatsRange(from, to string, now time.Time) (time.Time, time.Time, error) {
+	end := utcDay(now)
+	if to != "" {
+		t, err := time.Parse(statsDateLayout, to)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidStatsRange
+		}
+		end = t
+	}
+	start := end.AddDate(0, 0, -29)
+	if from != "" {
+		t, err := time.Parse(statsDateLayout, from)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidStatsRange
+		}
+		start = t
+	}
+	if start.After(end) || end.Sub(start) >= maxStatsRangeDays*24*time.Hour {
+		return time.Time{}, time.Time{}, ErrInvalidStatsRange
+	}
+	return start, end, nil
+}
+
+// RecordView counts a view of a published article. The visitor key (a user ID, or an
+// address and user agent) is only kept as a hash salted with a secret that changes daily.
+func (s *Service) RecordView(articleID, visitor string) error {
+	if _, err := uuid.Parse(articleID); err != nil {
+		return ErrArticleNotFound
+	}
+	day := utcDay(time.Now())
+	salt, err := s.salts.get(s.repo, day)
+	if err != nil {
+		return err
+	}
+	return s.repo.RecordView(articleID, day, visitorHash(salt, visitor))
+}
+
+// GetStats retrieves the views, likes and comments of an article per day for its author or an editor.
+// Dates are YYYY-MM-DD in UTC; the range defaults to the last 30 days.
+func (s *Service) GetStats(actor Actor, articleID, from, to string) (*ArticleStats, error) {
+	start, end, err := parseStatsRange(from, to, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.AuthorizeArticle(actor, articleID, ActionView); err != nil {
+		return nil, err
+	}
+
+	days, err := s.repo.ListDailyStats(articleID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &ArticleStats{
+		ArticleID: articleID,
+		From:      start.Format(statsDateLayout),
+		To:        end.Format(statsDateLayout),
+		Days:      days,
+	}
+	for _, d := range days {
+		stats.Views += d.Views
+		stats.Likes += d.Likes
+		stats.Comments += d.Comments
+	}
+	return stats, nil
+}
@@ -0,0 +1,56 @@
+package articles
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatsRange(t *testing.T) {
+	now := time.Date(2025, 11, 20, 23, 30, 0, 0, time.FixedZone("ALMT", 5*60*60))
+
+	from, to, err := parseStatsRange("", "", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 10, 22, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC), to)
+
+	from, to, err = parseStatsRange("2025-01-01", "2025-01-31", now)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-01", from.Format(statsDateLayout))
+	assert.Equal(t, "2025-01-31", to.Format(statsDateLayout))
+
+	for _, r := range [][2]string{{"2025-13-01", ""}, {"", "yesterday"}, {"2025-02-01", "2025-01-01"}, {"2024-01-01", "2025-01-01"}} {
+		_, _, err := parseStatsRange(r[0], r[1], now)
+		assert.ErrorIs(t, err, ErrInvalidStatsRange, r)
+	}
+}
+
+func TestVisitorHash(t *testing.T) {
+	hash := visitorHash([]byte("monday"), "anon:10.0.0.1|curl")
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, visitorHash([]byte("monday"), "anon:10.0.0.1|curl"))
+	assert.NotEqual(t, hash, visitorHash([]byte("tuesday"), "anon:10.0.0.1|curl"))
+	assert.NotContains(t, hash, "10.0.0.1")
+}
+
+func TestClientIP(t *testing.T) {
+	r := httptest.NewRequest("POST", "/api/articles/1/view", nil)
+	r.RemoteAddr = "192.0.2.1:5555"
+	assert.Equal(t, "192.0.2.1", clientIP(r, 0))
+
+	// Without trusted proxies a client-supplied header is ignored
+	r.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.2")
+	assert.Equal(t, "192.0.2.1", clientIP(r, 0))
+
+	// The proxy appends the address it saw; anything the client sent is to its left
+	assert.Equal(t, "10.0.0.2", clientIP(r, 1))
+	assert.Equal(t, "203.0.113.7", clientIP(r, 2))
+	r.Header.Add("X-Forwarded-For", "198.51.100.4")
+	assert.Equal(t, "198.51.100.4", clientIP(r, 1))
+
+	// A header shorter than the proxy chain falls back to the peer address
+	assert.Equal(t, "192.0.2.1", clientIP(r, 5))
+}
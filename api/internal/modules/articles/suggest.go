@@ -0,0 +1,198 @@
+package articles
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/lib/pq"
+)
+
+// maxSuggestLimit bounds the number of completions per group
+const maxSuggestLimit = 10
+
+// Suggestion is a completion for text typed into the search box
+type Suggestion struct {
+	// Value is what the client searches or filters by: an article ID, tag code or category ID
+	Value string `db:"value" json:"value"`
+	Label string `db:"label" json:"label"`
+	Slug  string `db:"slug" json:"slug,omitempty"`
+}
+
+// Suggestions groups completions by what they complete to
+type Suggestions struct {
+	Articles   []Suggestion `json:"articles"`
+	Tags       []Suggestion `json:"tags"`
+	Categories []Suggestion `json:"categories"`
+}
+
+// searchWords splits text into lower-case words of letters and digits
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// prefixQuery builds a to_tsquery expression matching documents that contain every word,
+// the last one possibly still being typed. Words hold only letters and digits, so they
+// cannot inject tsquery operators.
+func prefixQuery(words []string) string {
+	terms := make([]string, len(words))
+	for i, w := range words {
+		terms[i] = w + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// suggestQuery selects completions from a table by prefix match on its text, falling back
+// to trigram word similarity so a typo still finds it. $1 is the prefix tsquery, $2 the
+// typed text and $3 the limit.
+func suggestQuery(columns, from, where, text, order string) string {
+	return fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE %s
+		  AND (to_tsvector('simple', %s) @@ to_tsquery('simple', $1) OR $2 <%% (%s))
+		ORDER BY to_tsvector('simple', %s) @@ to_tsquery('simple', $1) DESC,
+			word_similarity($2, %s) DESC, %s
+		LIMIT $3
+	`, columns, from, where, text, text, text, text, order)
+}
+
+// tagNamesText and categoryNamesText hold the code and every translation of the name
+const (
+	tagNamesText      = `t.code || ' ' || COALESCE(t.name->>'en', '') || ' ' || COALESCE(t.name->>'ru', '') || ' ' || COALESCE(t.name->>'kk', '')`
+	categoryNamesText = `c.code || ' ' || COALESCE(c.name->>'en', '') || ' ' || COALESCE(c.name->>'ru', '') || ' ' || COALESCE(c.name->>'kk', '')`
+)
+
+// Suggest finds published article titles, tags and categories matching typed text.
+// Tag and category labels are in the given language, falling back to the code.
+func (r *Repository) Suggest(text, lang string, limit int) (*Suggestions, error) {
+	words := searchWords(text)
+	suggestions := &Suggestions{Articles: []Suggestion{}, Tags: []Suggestion{}, Categories: []Suggestion{}}
+	if len(words) == 0 {
+		return suggestions, nil
+	}
+	prefix := prefixQuery(words)
+	typed := strings.Join(words, " ")
+
+	articlesQuery := suggestQuery(
+		`a.id AS value, a.title AS label, a.slug`,
+		`articles a`,
+		`a.status = 'PUBLISHED' AND a.deleted_at IS NULL`,
+		`a.title`,
+		`a.published_at DESC`,
+	)
+	if err := r.db.Select(&suggestions.Articles, articlesQuery, prefix, typed, limit); err != nil {
+		return nil, err
+	}
+
+	tagsQuery := suggestQuery(
+		`t.code AS value, COALESCE(NULLIF(t.name->>$4, ''), t.code) AS label`,
+		`tags t`,
+		`TRUE`,
+		tagNamesText,
+		`t.code`,
+	)
+	if err := r.db.Select(&suggestions.Tags, tagsQuery, prefix, typed, limit, lang); err != nil {
+		return nil, err
+	}
+
+	categoriesQuery := suggestQuery(
+		`c.id AS value, COALESCE(NULLIF(c.name->>$4, ''), c.code) AS label`,
+		`categories c`,
+		`c.deleted_at IS NULL`,
+		categoryNamesText,
+		`c.code`,
+	)
+	if err := r.db.Select(&suggestions.Categories, categoriesQuery, prefix, typed, limit, lang); err != nil {
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
+// CorrectWords replaces each word missing from published articles with the most similar
+// word that is used, or an empty string when nothing is close enough
+func (r *Repository) CorrectWords(words []string) ([]string, error) {
+	query := `
+		SELECT COALESCE(
+			(SELECT word FROM search_terms WHERE word = w.input),
+			(SELECT word FROM search_terms WHERE word % w.input ORDER BY similarity(word, w.input) DESC, ndoc DESC LIMIT 1),
+			''
+		)
+		FROM unnest($1::text[]) WITH ORDINALITY AS w(input, pos)
+		ORDER BY w.pos
+	`
+	corrections := []string{}
+	err := r.db.Select(&corrections, query, pq.Array(words))
+	return corrections, err
+}
+
+// RefreshSearchTerms rebuilds the dictionary of words used in published articles
+func (r *Repository) RefreshSearchTerms(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, `REFRESH MATERIALIZED VIEW CONCURRENTLY search_terms`)
+	return err
+}
+
+// Suggest completes text typed into the search box with article titles, tags and categories.
+// Labels are in lang (English by default).
+func (s *Service) Suggest(text, lang string, limit int) (*Suggestions, error) {
+	if lang == "" {
+		lang = "en"
+	}
+	if !validLanguage(lang) {
+		return nil, ErrInvalidLanguage
+	}
+	if limit <= 0 || limit > maxSuggestLimit {
+		limit = 5
+	}
+	return s.repo.Suggest(text, lang, limit)
+}
+
+// DidYouMean proposes a corrected query when some of its words do not appear in any
+// published article, or returns an empty string when there is nothing to correct.
+// Phrases, negations and qualifiers are kept; tags and authors are left as typed.
+func (s *Service) DidYouMean(query string) (string, error) {
+	tokens := tokenizeSearchQuery(query)
+	words := []string{}
+	tokenWords := make([][]string, len(tokens))
+	for i, tok := range tokens {
+		if tok.textual() {
+			tokenWords[i] = searchWords(tok.Value)
+			words = append(words, tokenWords[i]...)
+		}
+	}
+	if len(words) == 0 {
+		return "", nil
+	}
+	corrections, err := s.repo.CorrectWords(words)
+	if err != nil {
+		return "", err
+	}
+
+	changed := false
+	next := 0
+	parts := make([]string, len(tokens))
+	for i, tok := range tokens {
+		if len(tokenWords[i]) > 0 {
+			fixed := make([]string, len(tokenWords[i]))
+			for j, w := range tokenWords[i] {
+				fixed[j] = w
+				if c := corrections[next]; c != "" && c != w {
+					fixed[j] = c
+					changed = true
+				}
+				next++
+			}
+			tok.Value = strings.Join(fixed, " ")
+			tok.Phrase = tok.Phrase || len(fixed) > 1
+		}
+		parts[i] = tok.String()
+	}
+	if !changed {
+		return "", nil
+	}
+	return strings.Join(parts, " "), nil
+}
@@ -0,0 +1,19 @@
+package articles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchWords(t *testing.T) {
+	assert.Equal(t, []string{"go", "1", "22", "release"}, searchWords("Go 1.22: release!"))
+	assert.Equal(t, []string{"нейронные", "сети"}, searchWords("Нейронные  сети"))
+	assert.Empty(t, searchWords(" & | ! :* "))
+}
+
+func TestPrefixQuery(t *testing.T) {
+	assert.Equal(t, "kuber:*", prefixQuery([]string{"kuber"}))
+	assert.Equal(t, "go:* & conc:*", prefixQuery(searchWords("Go conc")))
+	assert.Equal(t, "", prefixQuery(nil))
+}
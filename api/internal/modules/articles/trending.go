@@ -0,0 +1,150 @@
+package articles
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrInvalidWindow = errors.New("window must be 24h, 7d or 30d")
+
+// DefaultTrendingWindow is used when a request names no window
+const DefaultTrendingWindow = "7d"
+
+// Weights of reader activity in the trending score; views count unique visitors per day
+const (
+	viewWeight    = 1.0
+	likeWeight    = 3.0
+	dislikeWeight = -2.0
+	commentWeight = 4.0
+)
+
+// TrendingWindow is a ranking period. Activity older than Span is ignored and
+// the weight of an event halves every HalfLife.
+type TrendingWindow struct {
+	Name     string
+	Span     time.Duration
+	HalfLife time.Duration
+}
+
+// TrendingWindows lists the ranking periods that are kept up to date
+var TrendingWindows = []TrendingWindow{
+	{Name: "24h", Span: 24 * time.Hour, HalfLife: 6 * time.Hour},
+	{Name: "7d", Span: 7 * 24 * time.Hour, HalfLife: 36 * time.Hour},
+	{Name: "30d", Span: 30 * 24 * time.Hour, HalfLife: 5 * 24 * time.Hour},
+}
+
+// popularTagsWindow is the period whose scores rank popular tags
+const popularTagsWindow = "30d"
+
+// TrendingArticle is a published article with its score in a ranking period
+type TrendingArticle struct {
+	Article
+	Score float64 `db:"score" json:"score"`
+}
+
+// RefreshTrending recomputes the scores of a ranking period as of now. Views are taken
+// from the daily stats and dated at midday of their day; articles scoring zero or less are dropped.
+// Only one API replica refreshes a period at a time; the others skip the run.
+func (r *Repository) RefreshTrending(ctx context.Context, window TrendingWindow, now time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err := tx.GetContext(ctx, &locked, `SELECT pg_try_advisory_xact_lock(hashtext('article_trending:' || $1))`, window.Name); err != nil {
+		return err
+	}
+	if !locked {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM article_trending WHERE period = $1`, window.Name); err != nil {
+		return err
+	}
+
+	query := `
+		WITH events AS (
+			SELECT article_id, (day + INTERVAL '12 hours') AT TIME ZONE 'UTC' AS at, views * $5::float8 AS weight
+			FROM article_daily_stats
+			WHERE views > 0 AND day >= (($2::timestamptz - $3::float8 * INTERVAL '1 second') AT TIME ZONE 'UTC')::date
+			UNION ALL
+			SELECT article_id, created_at, CASE WHEN is_like THEN $6::float8 ELSE $7::float8 END
+			FROM article_likes
+			WHERE created_at >= $2::timestamptz - $3::float8 * INTERVAL '1 second'
+			UNION ALL
+			SELECT article_id, created_at, $8::float8
+			FROM comments
+			WHERE status = 'APPROVED' AND deleted_at IS NULL AND created_at >= $2::timestamptz - $3::float8 * INTERVAL '1 second'
+		)
+		INSERT INTO article_trending (period, article_id, score)
+		SELECT $1, e.article_id,
+			SUM(e.weight * EXP(-LN(2) * GREATEST(EXTRACT(EPOCH FROM ($2::timestamptz - e.at)), 0) / $4::float8)) AS score
+		FROM events e
+		JOIN articles a ON a.id = e.article_id
+		WHERE a.status = 'PUBLISHED' AND a.deleted_at IS NULL
+		GROUP BY e.article_id
+		HAVING SUM(e.weight * EXP(-LN(2) * GREATEST(EXTRACT(EPOCH FROM ($2::timestamptz - e.at)), 0) / $4::float8)) > 0
+	`
+	_, err = tx.ExecContext(ctx, query,
+		window.Name,
+		now,
+		window.Span.Seconds(),
+		window.HalfLife.Seconds(),
+		viewWeight,
+		likeWeight,
+		dislikeWeight,
+		commentWeight,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListTrending retrieves the highest scoring published articles of a ranking period
+func (r *Repository) ListTrending(window string, limit int) ([]TrendingArticle, error) {
+	query := `
+		SELECT a.id, a.title, a.slug, a.body, a.category_id, a.author_id, a.status, a.language, a.published_at,
+			a.created_at, a.updated_at, a.likes_count, a.dislikes_count, a.comments_count, t.score
+		FROM article_trending t
+		JOIN articles a ON a.id = t.article_id
+		WHERE t.period = $1 AND a.status = 'PUBLISHED' AND a.deleted_at IS NULL
+		ORDER BY t.score DESC, a.published_at DESC
+		LIMIT $2
+	`
+	articles := []TrendingArticle{}
+	err := r.db.Select(&articles, query, window, limit)
+	return articles, err
+}
+
+// trendingWindow looks up a ranking period by name
+func trendingWindow(name string) (TrendingWindow, bool) {
+	for _, w := range TrendingWindows {
+		if w.Name == name {
+			return w, true
+		}
+	}
+	return TrendingWindow{}, false
+}
+
+// Trending retrieves the top articles of a ranking period with text previews
+func (s *Service) Trending(window string, limit int) ([]TrendingArticle, error) {
+	if window == "" {
+		window = DefaultTrendingWindow
+	}
+	if _, ok := trendingWindow(window); !ok {
+		return nil, ErrInvalidWindow
+	}
+
+	articles, err := s.repo.ListTrending(window, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range articles {
+		articles[i].Body = generatePreview(articles[i].Body)
+	}
+	return articles, nil
+}
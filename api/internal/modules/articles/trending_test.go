@@ -0,0 +1,26 @@
+package articles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrendingWindow(t *testing.T) {
+	for _, name := range []string{"24h", "7d", "30d"} {
+		window, ok := trendingWindow(name)
+		assert.True(t, ok, name)
+		assert.Less(t, window.HalfLife, window.Span, name)
+	}
+
+	_, ok := trendingWindow("1y")
+	assert.False(t, ok)
+
+	service := &Service{}
+	_, err := service.Trending("90d", 10)
+	assert.ErrorIs(t, err, ErrInvalidWindow)
+	_, ok = trendingWindow(DefaultTrendingWindow)
+	assert.True(t, ok)
+	assert.Equal(t, 30*24*time.Hour, TrendingWindows[len(TrendingWindows)-1].Span)
+}
@@ -0,0 +1,82 @@
+package articles
+
+import "time"
+
+// Article statuses of the editorial workflow:
+// DRAFT → IN_REVIEW → APPROVED/CHANGES_REQUESTED → PUBLISHED → ARCHIVED
+const (
+	StatusDraft            = "DRAFT"
+	StatusInReview         = "IN_REVIEW"
+	StatusChangesRequested = "CHANGES_REQUESTED"
+	StatusApproved         = "APPROVED"
+	StatusPublished        = "PUBLISHED"
+	StatusArchived         = "ARCHIVED"
+)
+
+// validStatuses lists every status accepted by the articles_status_check constraint
+var validStatuses = map[string]bool{
+	StatusDraft:            true,
+	StatusInReview:         true,
+	StatusChangesRequested: true,
+	StatusApproved:         true,
+	StatusPublished:        true,
+	StatusArchived:         true,
+}
+
+// Workflow transition names, used as endpoint suffixes
+const (
+	TransitionSubmit         = "submit"
+	TransitionApprove        = "approve"
+	TransitionRequestChanges = "request-changes"
+	TransitionPublish        = "publish"
+	TransitionArchive        = "archive"
+)
+
+// transition describes an allowed move between statuses and who may perform it
+type transition struct {
+	from   []string
+	to     string
+	action Action
+}
+
+var transitions = map[string]transition{
+	TransitionSubmit:         {from: []string{StatusDraft, StatusChangesRequested}, to: StatusInReview, action: ActionSubmit},
+	TransitionApprove:        {from: []string{StatusInReview}, to: StatusApproved, action: ActionReview},
+	TransitionRequestChanges: {from: []string{StatusInReview}, to: StatusChangesRequested, action: ActionReview},
+	TransitionPublish:        {from: []string{StatusApproved}, to: StatusPublished, action: ActionPublish},
+	TransitionArchive:        {from: []string{StatusPublished}, to: StatusArchived, action: ActionPublish},
+}
+
+// StatusTransition is a recorded move of an article between workflow states
+type StatusTransition struct {
+	ID         string    `db:"id" json:"id"`
+	ArticleID  string    `db:"article_id" json:"article_id"`
+	FromStatus string    `db:"from_status" json:"from_status"`
+	ToStatus   string    `db:"to_status" json:"to_status"`
+	ActorID    *string   `db:"actor_id" json:"actor_id"`
+	Note       *string   `db:"note" json:"note,omitempty"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+}
+
+// ReviewNote is a private note on an article visible only to its author and editors
+type ReviewNote struct {
+	ID        string    `db:"id" json:"id"`
+	ArticleID string    `db:"article_id" json:"article_id"`
+	AuthorID  string    `db:"author_id" json:"author_id"`
+	Body      string    `db:"body" json:"body"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// nextStatus returns the target status of a named transition from the current status
+func nextStatus(name, current string) (string, Action, error) {
+	t, ok := transitions[name]
+	if !ok {
+		return "", "", ErrInvalidTransition
+	}
+	for _, from := range t.from {
+		if from == current {
+			return t.to, t.action, nil
+		}
+	}
+	return "", "", ErrInvalidTransition
+}
@@ -0,0 +1,42 @@
+package articles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextStatus(t *testing.T) {
+	tests := []struct {
+		transition string
+		from       string
+		to         string
+		action     Action
+		valid      bool
+	}{
+		{TransitionSubmit, StatusDraft, StatusInReview, ActionSubmit, true},
+		{TransitionSubmit, StatusChangesRequested, StatusInReview, ActionSubmit, true},
+		{TransitionSubmit, StatusPublished, "", "", false},
+		{TransitionApprove, StatusInReview, StatusApproved, ActionReview, true},
+		{TransitionApprove, StatusDraft, "", "", false},
+		{TransitionRequestChanges, StatusInReview, StatusChangesRequested, ActionReview, true},
+		{TransitionPublish, StatusApproved, StatusPublished, ActionPublish, true},
+		{TransitionPublish, StatusDraft, "", "", false},
+		{TransitionPublish, StatusInReview, "", "", false},
+		{TransitionArchive, StatusPublished, StatusArchived, ActionPublish, true},
+		{"unknown", StatusDraft, "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.transition+" from "+tt.from, func(t *testing.T) {
+			to, action, err := nextStatus(tt.transition, tt.from)
+			if !tt.valid {
+				assert.ErrorIs(t, err, ErrInvalidTransition)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.to, to)
+			assert.Equal(t, tt.action, action)
+		})
+	}
+}
@@ -0,0 +1,302 @@
+package news
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var ErrUnsupportedFeed = errors.New("unsupported feed format")
+
+// maxSummaryLength limits the plain text summary taken from a feed entry
+const maxSummaryLength = 300
+
+// Feed is a parsed RSS 2.0 or Atom document
+type Feed struct {
+	Title   string
+	Entries []FeedEntry
+}
+
+// FeedEntry is a feed item normalized to the fields a news item needs
+type FeedEntry struct {
+	Title       string
+	Summary     string
+	URL         string
+	ImageURL    string
+	PublishedAt *time.Time
+}
+
+type mediaRef struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Medium string `xml:"medium,attr"`
+}
+
+type rssDocument struct {
+	Channel struct {
+		Title string    `xml:"title"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type rssItem struct {
+	Title       string     `xml:"title"`
+	Link        string     `xml:"link"`
+	GUID        string     `xml:"guid"`
+	Description string     `xml:"description"`
+	Content     string     `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	PubDate     string     `xml:"pubDate"`
+	Enclosures  []mediaRef `xml:"enclosure"`
+	Media       []mediaRef `xml:"http://search.yahoo.com/mrss/ content"`
+	Thumbnails  []mediaRef `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+type atomDocument struct {
+	Title   string      `xml:"title"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title string `xml:"title"`
+	Links []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+		Type string `xml:"type,attr"`
+	} `xml:"link"`
+	Summary    string     `xml:"summary"`
+	Content    string     `xml:"content"`
+	Published  string     `xml:"published"`
+	Updated    string     `xml:"updated"`
+	Media      []mediaRef `xml:"http://search.yahoo.com/mrss/ content"`
+	Thumbnails []mediaRef `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+// ParseFeed parses an RSS 2.0 or Atom document, detected by its root element
+func ParseFeed(r io.Reader) (*Feed, error) {
+	decoder := xml.NewDecoder(r)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("read feed: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "rss":
+			var doc rssDocument
+			if err := decoder.DecodeElement(&doc, &start); err != nil {
+				return nil, fmt.Errorf("decode rss: %w", err)
+			}
+			return doc.normalize(), nil
+		case "feed":
+			var doc atomDocument
+			if err := decoder.DecodeElement(&doc, &start); err != nil {
+				return nil, fmt.Errorf("decode atom: %w", err)
+			}
+			return doc.normalize(), nil
+		default:
+			return nil, ErrUnsupportedFeed
+		}
+	}
+}
+
+func (doc rssDocument) normalize() *Feed {
+	feed := &Feed{Title: strings.TrimSpace(doc.Channel.Title)}
+	for _, item := range doc.Channel.Items {
+		link := strings.TrimSpace(item.Link)
+		if link == "" && strings.HasPrefix(item.GUID, "http") {
+			link = strings.TrimSpace(item.GUID)
+		}
+		summary := item.Description
+		if summary == "" {
+			summary = item.Content
+		}
+		feed.Entries = append(feed.Entries, FeedEntry{
+			Title:       plainText(item.Title, 0),
+			Summary:     plainText(summary, maxSummaryLength),
+			URL:         link,
+			ImageURL:    pickImage(item.Enclosures, item.Media, item.Thumbnails),
+			PublishedAt: parseFeedTime(item.PubDate),
+		})
+	}
+	return feed
+}
+
+func (doc atomDocument) normalize() *Feed {
+	feed := &Feed{Title: plainText(doc.Title, 0)}
+	for _, entry := range doc.Entries {
+		var link, image string
+		for _, l := range entry.Links {
+			switch {
+			case (l.Rel == "" || l.Rel == "alternate") && link == "":
+				link = strings.TrimSpace(l.Href)
+			case l.Rel == "enclosure" && strings.HasPrefix(l.Type, "image/") && image == "":
+				image = strings.TrimSpace(l.Href)
+			}
+		}
+		if image == "" {
+			image = pickImage(nil, entry.Media, entry.Thumbnails)
+		}
+		summary := entry.Summary
+		if summary == "" {
+			summary = entry.Content
+		}
+		published := parseFeedTime(entry.Published)
+		if published == nil {
+			published = parseFeedTime(entry.Updated)
+		}
+		feed.Entries = append(feed.Entries, FeedEntry{
+			Title:       plainText(entry.Title, 0),
+			Summary:     plainText(summary, maxSummaryLength),
+			URL:         link,
+			ImageURL:    image,
+			PublishedAt: published,
+		})
+	}
+	return feed
+}
+
+// pickImage returns the first image among enclosures, media:content and media:thumbnail
+func pickImage(enclosures, media, thumbnails []mediaRef) string {
+	for _, e := range enclosures {
+		if strings.HasPrefix(e.Type, "image/") {
+			return strings.TrimSpace(e.URL)
+		}
+	}
+	for _, m := range media {
+		if m.Medium == "image" || strings.HasPrefix(m.Type, "image/") {
+			return strings.TrimSpace(m.URL)
+		}
+	}
+	for _, t := range thumbnails {
+		if t.URL != "" {
+			return strings.TrimSpace(t.URL)
+		}
+	}
+	return ""
+}
+
+var feedTimeLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	time.RFC3339Nano,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2006-01-02T15:04:05",
+}
+
+// parseFeedTime parses the date formats seen in RSS and Atom feeds
+func parseFeedTime(value string) *time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	for _, layout := range feedTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			t = t.UTC()
+			return &t
+		}
+	}
+	return nil
+}
+
+var (
+	htmlTagPattern    = regexp.MustCompile(`<[^>]*>`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// plainText strips markup, decodes entities and collapses whitespace,
+// cutting the result at a word boundary when it exceeds limit runes
+func plainText(s string, limit int) string {
+	s = htmlTagPattern.ReplaceAllString(s, " ")
+	s = html.UnescapeString(s)
+	s = strings.TrimSpace(whitespacePattern.ReplaceAllString(s, " "))
+
+	runes := []rune(s)
+	if limit <= 0 || len(runes) <= limit {
+		return s
+	}
+	cut := string(runes[:limit])
+	if i := strings.LastIndex(cut, " "); i > limit/2 {
+		cut = cut[:i]
+	}
+	return cut + "..."
+}
+
+// trackingParams are query parameters that do not change the linked content
+var trackingParams = map[string]bool{
+	"fbclid": true,
+	"gclid":  true,
+	"yclid":  true,
+	"mc_cid": true,
+	"mc_eid": true,
+	"ref":    true,
+}
+
+// CanonicalURL resolves a link against the feed URL and normalizes it so that
+// the same article linked with different tracking parameters compares equal
+func CanonicalURL(link, base string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return "", err
+	}
+	if !u.IsAbs() && base != "" {
+		baseURL, err := url.Parse(base)
+		if err != nil {
+			return "", err
+		}
+		u = baseURL.ResolveReference(u)
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("unsupported url %q", link)
+	}
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && !(u.Scheme == "http" && port == "80") && !(u.Scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	u.Host = host
+	u.User = nil
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	query := u.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") || trackingParams[strings.ToLower(key)] {
+			query.Del(key)
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	if len(u.Path) > 1 {
+		u.Path = strings.TrimRight(u.Path, "/")
+		u.RawPath = ""
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u.String(), nil
+}
+
+// ContentHash fingerprints an entry by its normalized title and summary, catching
+// the same story republished under a different URL
+func ContentHash(title, summary string) string {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.TrimSpace(whitespacePattern.ReplaceAllString(s, " ")))
+	}
+	sum := sha256.Sum256([]byte(normalize(title) + "\n" + normalize(summary)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,111 @@
+package news
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRSS = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+  <channel>
+    <title>AI Weekly</title>
+    <item>
+      <title>New &amp; improved model</title>
+      <link>https://example.com/posts/new-model/?utm_source=rss&amp;id=7</link>
+      <description><![CDATA[<p>The <b>model</b> is out.</p>]]></description>
+      <pubDate>Tue, 18 Nov 2025 09:30:00 +0000</pubDate>
+      <media:content url="https://example.com/cover.jpg" medium="image"/>
+    </item>
+    <item>
+      <title>GUID only</title>
+      <guid>https://example.com/posts/guid-only</guid>
+      <content:encoded>Body text</content:encoded>
+      <enclosure url="https://example.com/img.png" type="image/png"/>
+    </item>
+  </channel>
+</rss>`
+
+const testAtom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Research Blog</title>
+  <entry>
+    <title>Atom entry</title>
+    <link rel="alternate" href="/blog/atom-entry"/>
+    <link rel="enclosure" type="image/jpeg" href="https://research.example.org/a.jpg"/>
+    <summary>Short summary</summary>
+    <updated>2025-11-17T12:00:00Z</updated>
+  </entry>
+</feed>`
+
+func TestParseFeed_RSS(t *testing.T) {
+	feed, err := ParseFeed(strings.NewReader(testRSS))
+	require.NoError(t, err)
+
+	assert.Equal(t, "AI Weekly", feed.Title)
+	require.Len(t, feed.Entries, 2)
+
+	first := feed.Entries[0]
+	assert.Equal(t, "New & improved model", first.Title)
+	assert.Equal(t, "The model is out.", first.Summary)
+	assert.Equal(t, "https://example.com/cover.jpg", first.ImageURL)
+	require.NotNil(t, first.PublishedAt)
+	assert.True(t, first.PublishedAt.Equal(time.Date(2025, 11, 18, 9, 30, 0, 0, time.UTC)))
+
+	second := feed.Entries[1]
+	assert.Equal(t, "https://example.com/posts/guid-only", second.URL)
+	assert.Equal(t, "Body text", second.Summary)
+	assert.Equal(t, "https://example.com/img.png", second.ImageURL)
+	assert.Nil(t, second.PublishedAt)
+}
+
+func TestParseFeed_Atom(t *testing.T) {
+	feed, err := ParseFeed(strings.NewReader(testAtom))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Research Blog", feed.Title)
+	require.Len(t, feed.Entries, 1)
+	entry := feed.Entries[0]
+	assert.Equal(t, "/blog/atom-entry", entry.URL)
+	assert.Equal(t, "https://research.example.org/a.jpg", entry.ImageURL)
+	require.NotNil(t, entry.PublishedAt)
+	assert.Equal(t, 2025, entry.PublishedAt.Year())
+}
+
+func TestParseFeed_Unsupported(t *testing.T) {
+	_, err := ParseFeed(strings.NewReader(`<html><body>not a feed</body></html>`))
+	assert.ErrorIs(t, err, ErrUnsupportedFeed)
+}
+
+func TestCanonicalURL(t *testing.T) {
+	tests := []struct {
+		link, base, want string
+	}{
+		{"https://Example.com/posts/a/?utm_source=rss&b=2&a=1#top", "", "https://example.com/posts/a?a=1&b=2"},
+		{"HTTP://example.com:80", "", "http://example.com/"},
+		{"/blog/x", "https://research.example.org/feed.xml", "https://research.example.org/blog/x"},
+		{"https://example.com/p?fbclid=abc", "", "https://example.com/p"},
+	}
+	for _, tt := range tests {
+		got, err := CanonicalURL(tt.link, tt.base)
+		require.NoError(t, err, tt.link)
+		assert.Equal(t, tt.want, got, tt.link)
+	}
+
+	_, err := CanonicalURL("mailto:someone@example.com", "")
+	assert.Error(t, err)
+}
+
+func TestContentHash(t *testing.T) {
+	assert.Equal(t, ContentHash("Big  News", "Details here"), ContentHash("big news", " details   here "))
+	assert.NotEqual(t, ContentHash("Big News", "Details here"), ContentHash("Big News", "Other details"))
+}
+
+func TestPlainText_Truncates(t *testing.T) {
+	text := plainText(strings.Repeat("word ", 100), 50)
+	assert.True(t, strings.HasSuffix(text, "..."))
+	assert.LessOrEqual(t, len(text), 53)
+}
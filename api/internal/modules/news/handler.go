@@ -0,0 +1,223 @@
+package news
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ai-dala/api/internal/auth"
+)
+
+type Handler struct {
+	service  *Service
+	verifier *auth.Verifier
+}
+
+func NewHandler(service *Service, verifier *auth.Verifier) *Handler {
+	return &Handler{service: service, verifier: verifier}
+}
+
+// RegisterRoutes registers the editor routes for news; the public site reads news via /api/content/news
+func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+	mux.Handle("GET /api/news", h.editor(auth.PermManageContent, h.handleList))
+	mux.Handle("POST /api/news", h.editor(auth.PermManageContent, h.handleCreate))
+	mux.Handle("GET /api/news/{id}", h.editor(auth.PermManageContent, h.handleGet))
+	mux.Handle("PUT /api/news/{id}", h.editor(auth.PermManageContent, h.handleUpdate))
+	mux.Handle("DELETE /api/news/{id}", h.editor(auth.PermManageContent, h.handleDelete))
+	mux.Handle("POST /api/news/{id}/publish", h.editor(auth.PermPublishContent, h.handlePublish))
+	mux.Handle("POST /api/news/{id}/archive", h.editor(auth.PermPublishContent, h.handleArchive))
+}
+
+// editor restricts a handler to authenticated users holding the permission
+func (h *Handler) editor(perm auth.Permission, handler http.HandlerFunc) http.Handler {
+	return h.verifier.Middleware(auth.RequirePermission(perm)(handler))
+}
+
+// newsRequest is the editable part of a news item
+type newsRequest struct {
+	Title      string   `json:"title"`
+	Slug       string   `json:"slug"`
+	Summary    string   `json:"summary"`
+	Body       string   `json:"body"`
+	ImageURL   string   `json:"image_url"`
+	CategoryID *string  `json:"category_id"`
+	TagIDs     []string `json:"tag_ids"`
+}
+
+func (req newsRequest) toNews() *News {
+	return &News{
+		Title:      req.Title,
+		Slug:       req.Slug,
+		Summary:    req.Summary,
+		Body:       req.Body,
+		ImageURL:   req.ImageURL,
+		CategoryID: req.CategoryID,
+	}
+}
+
+// writeServiceError maps service errors to HTTP status codes
+func writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidNews):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrNewsNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrStatusConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// writeNews writes a news item together with its tags
+func (h *Handler) writeNews(w http.ResponseWriter, status int, item *News) {
+	tags, err := h.service.GetTags(item.ID)
+	if err != nil {
+		tags = []string{}
+	}
+
+	response := map[string]interface{}{
+		"news": item,
+		"tags": tags,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleList lists news of any status for editors
+func (h *Handler) handleList(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	category := r.URL.Query().Get("category")
+
+	limit := 20
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+	page := 1
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	items, total, err := h.service.List(status, category, limit, (page-1)*limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"news":  items,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleGet retrieves a single news item by ID
+func (h *Handler) handleGet(w http.ResponseWriter, r *http.Request) {
+	item, err := h.service.FindByID(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if item == nil {
+		http.Error(w, ErrNewsNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	h.writeNews(w, http.StatusOK, item)
+}
+
+// handleCreate creates a draft news item
+func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req newsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	authorID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	item := req.toNews()
+	item.AuthorID = &authorID
+	if err := h.service.Create(item); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	if len(req.TagIDs) > 0 {
+		if err := h.service.SetTags(item.ID, req.TagIDs); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.writeNews(w, http.StatusCreated, item)
+}
+
+// handleUpdate replaces the content of a news item; tag_ids, when present, replace its tags
+func (h *Handler) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var req newsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	item := req.toNews()
+	if err := h.service.Update(id, item); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	if req.TagIDs != nil {
+		if err := h.service.SetTags(id, req.TagIDs); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.writeNews(w, http.StatusOK, item)
+}
+
+// handleDelete soft-deletes a news item
+func (h *Handler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Delete(r.PathValue("id")); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handlePublish publishes a news item
+func (h *Handler) handlePublish(w http.ResponseWriter, r *http.Request) {
+	item, err := h.service.Publish(r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	h.writeNews(w, http.StatusOK, item)
+}
+
+// handleArchive archives a news item
+func (h *Handler) handleArchive(w http.ResponseWriter, r *http.Request) {
+	item, err := h.service.Archive(r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	h.writeNews(w, http.StatusOK, item)
+}
@@ -0,0 +1,184 @@
+package news
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// maxFeedSize bounds how much of a feed response is read
+const maxFeedSize = 10 << 20
+
+// FeedSource is an RSS or Atom feed polled for news
+type FeedSource struct {
+	Name string
+	URL  string
+}
+
+// FeedSourcesFromEnv reads NEWS_FEEDS, a comma-separated list of feed URLs,
+// each optionally prefixed with a display name as "name|url"
+func FeedSourcesFromEnv() []FeedSource {
+	var sources []FeedSource
+	for _, entry := range strings.Split(os.Getenv("NEWS_FEEDS"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		source := FeedSource{URL: entry}
+		if name, feedURL, ok := strings.Cut(entry, "|"); ok {
+			source = FeedSource{Name: strings.TrimSpace(name), URL: strings.TrimSpace(feedURL)}
+		}
+		sources = append(sources, source)
+	}
+	return sources
+}
+
+// DraftStore is where the ingester checks for and stores imported news
+type DraftStore interface {
+	IsDuplicate(sourceURL, contentHash string) (bool, error)
+	CreateIngested(item *News) error
+}
+
+// IngestResult counts what happened to the entries of one ingestion run
+type IngestResult struct {
+	Fetched    int
+	Created    int
+	Duplicates int
+	Skipped    int
+}
+
+// Ingester periodically polls feed sources and stores new entries as draft news
+type Ingester struct {
+	store    DraftStore
+	sources  []FeedSource
+	client   *http.Client
+	interval time.Duration
+}
+
+func NewIngester(store DraftStore, sources []FeedSource, interval time.Duration) *Ingester {
+	if interval <= 0 {
+		interval = 30 * time.Minute
+	}
+	return &Ingester{
+		store:    store,
+		sources:  sources,
+		client:   &http.Client{Timeout: 15 * time.Second},
+		interval: interval,
+	}
+}
+
+// Run polls all sources every interval until the context is cancelled
+func (i *Ingester) Run(ctx context.Context) {
+	ticker := time.NewTicker(i.interval)
+	defer ticker.Stop()
+
+	log.Printf("[INGEST] Started with %d sources, interval %s", len(i.sources), i.interval)
+	for {
+		result, err := i.RunOnce(ctx)
+		if err != nil {
+			log.Printf("[INGEST] Run failed: %v", err)
+		}
+		log.Printf("[INGEST] Fetched %d entries: %d created, %d duplicates, %d skipped",
+			result.Fetched, result.Created, result.Duplicates, result.Skipped)
+
+		select {
+		case <-ctx.Done():
+			log.Println("[INGEST] Stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce polls every source once. A failing source does not stop the others;
+// their errors are joined in the returned error.
+func (i *Ingester) RunOnce(ctx context.Context) (IngestResult, error) {
+	var result IngestResult
+	var errs []error
+	for _, source := range i.sources {
+		if err := i.ingestSource(ctx, source, &result); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", source.URL, err))
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+func (i *Ingester) ingestSource(ctx context.Context, source FeedSource, result *IngestResult) error {
+	feed, err := i.fetch(ctx, source.URL)
+	if err != nil {
+		return err
+	}
+
+	sourceName := source.Name
+	if sourceName == "" {
+		sourceName = feed.Title
+	}
+
+	for _, entry := range feed.Entries {
+		result.Fetched++
+		if entry.Title == "" || entry.URL == "" {
+			result.Skipped++
+			continue
+		}
+		canonical, err := CanonicalURL(entry.URL, source.URL)
+		if err != nil {
+			result.Skipped++
+			continue
+		}
+		hash := ContentHash(entry.Title, entry.Summary)
+
+		duplicate, err := i.store.IsDuplicate(canonical, hash)
+		if err != nil {
+			return err
+		}
+		if duplicate {
+			result.Duplicates++
+			continue
+		}
+
+		item := &News{
+			Title:       entry.Title,
+			Summary:     entry.Summary,
+			ImageURL:    entry.ImageURL,
+			PublishedAt: entry.PublishedAt,
+			SourceName:  sourceName,
+			SourceURL:   &canonical,
+			ContentHash: &hash,
+		}
+		if err := i.store.CreateIngested(item); err != nil {
+			if errors.Is(err, ErrDuplicateNews) {
+				result.Duplicates++
+				continue
+			}
+			return err
+		}
+		result.Created++
+		log.Printf("[INGEST] Imported draft news %s from %s", item.ID, sourceName)
+	}
+	return nil
+}
+
+func (i *Ingester) fetch(ctx context.Context, feedURL string) (*Feed, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return ParseFeed(io.LimitReader(resp.Body, maxFeedSize))
+}
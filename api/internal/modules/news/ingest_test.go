@@ -0,0 +1,91 @@
+package news
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore is an in-memory DraftStore
+type memoryStore struct {
+	items []*News
+}
+
+func (m *memoryStore) IsDuplicate(sourceURL, contentHash string) (bool, error) {
+	for _, item := range m.items {
+		if *item.SourceURL == sourceURL || *item.ContentHash == contentHash {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memoryStore) CreateIngested(item *News) error {
+	item.ID = fmt.Sprintf("news-%d", len(m.items)+1)
+	item.Status = StatusDraft
+	m.items = append(m.items, item)
+	return nil
+}
+
+func TestIngester_RunOnce(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rss", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprint(w, testRSS)
+	})
+	mux.HandleFunc("/atom", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/atom+xml")
+		fmt.Fprint(w, testAtom)
+	})
+	// The same story syndicated under another URL is caught by the content hash
+	mux.HandleFunc("/mirror", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<rss version="2.0"><channel><title>Mirror</title>
+			<item><title>New &amp; improved model</title><link>https://mirror.example.net/1</link>
+			<description>The model is out.</description></item>
+		</channel></rss>`)
+	})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	feeds := httptest.NewServer(mux)
+	defer feeds.Close()
+
+	store := &memoryStore{}
+	ingester := NewIngester(store, []FeedSource{
+		{URL: feeds.URL + "/rss"},
+		{Name: "Research", URL: feeds.URL + "/atom"},
+		{URL: feeds.URL + "/mirror"},
+		{URL: feeds.URL + "/broken"},
+	}, 0)
+
+	result, err := ingester.RunOnce(context.Background())
+	assert.ErrorContains(t, err, "/broken")
+	assert.Equal(t, IngestResult{Fetched: 4, Created: 3, Duplicates: 1}, result)
+
+	require.Len(t, store.items, 3)
+	assert.Equal(t, "AI Weekly", store.items[0].SourceName)
+	assert.Equal(t, "https://example.com/posts/new-model?id=7", *store.items[0].SourceURL)
+	assert.Equal(t, "Research", store.items[2].SourceName)
+	assert.Equal(t, feeds.URL+"/blog/atom-entry", *store.items[2].SourceURL)
+	for _, item := range store.items {
+		assert.Equal(t, StatusDraft, item.Status)
+	}
+
+	// A second poll finds nothing new
+	result, _ = ingester.RunOnce(context.Background())
+	assert.Equal(t, 0, result.Created)
+	assert.Equal(t, 4, result.Duplicates)
+}
+
+func TestFeedSourcesFromEnv(t *testing.T) {
+	t.Setenv("NEWS_FEEDS", "https://a.example.com/rss, Research|https://b.example.com/atom,")
+	assert.Equal(t, []FeedSource{
+		{URL: "https://a.example.com/rss"},
+		{Name: "Research", URL: "https://b.example.com/atom"},
+	}, FeedSourcesFromEnv())
+}
@@ -0,0 +1,244 @@
+package news
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type News struct {
+	ID          string     `db:"id" json:"id"`
+	Title       string     `db:"title" json:"title"`
+	Slug        string     `db:"slug" json:"slug"`
+	Summary     string     `db:"summary" json:"summary"`
+	Body        string     `db:"body" json:"body"`
+	ImageURL    string     `db:"image_url" json:"image_url"`
+	CategoryID  *string    `db:"category_id" json:"category_id"`
+	AuthorID    *string    `db:"author_id" json:"author_id"`
+	Status      string     `db:"status" json:"status"`
+	SourceName  string     `db:"source_name" json:"source_name,omitempty"`
+	SourceURL   *string    `db:"source_url" json:"source_url,omitempty"`
+	ContentHash *string    `db:"content_hash" json:"-"`
+	PublishedAt *time.Time `db:"published_at" json:"published_at,omitempty"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
+	DeletedAt   *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+}
+
+type FilterOptions struct {
+	Status string
+	// Category matches either the category code or a tag code
+	Category string
+	Limit    int
+	Offset   int
+}
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const newsColumns = `n.id, n.title, n.slug, n.summary, n.body, n.image_url, n.category_id, n.author_id, n.status, n.source_name, n.source_url, n.content_hash, n.published_at, n.created_at, n.updated_at, n.deleted_at`
+
+// Create inserts a news item
+func (r *Repository) Create(item *News) error {
+	query := `
+		INSERT INTO news (title, slug, summary, body, image_url, category_id, author_id, status, published_at, source_name, source_url, content_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id, created_at, updated_at
+	`
+	return r.db.QueryRow(
+		query,
+		item.Title,
+		item.Slug,
+		item.Summary,
+		item.Body,
+		item.ImageURL,
+		item.CategoryID,
+		item.AuthorID,
+		item.Status,
+		item.PublishedAt,
+		item.SourceName,
+		item.SourceURL,
+		item.ContentHash,
+	).Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt)
+}
+
+// FindByID retrieves a news item by ID (excluding soft-deleted)
+func (r *Repository) FindByID(id string) (*News, error) {
+	var item News
+	query := `SELECT ` + newsColumns + ` FROM news n WHERE n.id = $1 AND n.deleted_at IS NULL`
+	err := r.db.Get(&item, query, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &item, err
+}
+
+// FindBySlug retrieves a news item by slug (excluding soft-deleted)
+func (r *Repository) FindBySlug(slug string) (*News, error) {
+	var item News
+	query := `SELECT ` + newsColumns + ` FROM news n WHERE n.slug = $1 AND n.deleted_at IS NULL`
+	err := r.db.Get(&item, query, slug)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &item, err
+}
+
+// SlugTaken reports whether another news item, including soft-deleted ones, uses the slug
+func (r *Repository) SlugTaken(slug, excludeID string) (bool, error) {
+	var taken bool
+	query := `SELECT EXISTS (SELECT 1 FROM news WHERE slug = $1 AND ($2 = '' OR id::text <> $2))`
+	err := r.db.Get(&taken, query, slug, excludeID)
+	return taken, err
+}
+
+// IsDuplicate reports whether a news item, including soft-deleted ones, was already
+// imported from the canonical URL or with the same content hash
+func (r *Repository) IsDuplicate(sourceURL, contentHash string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM news WHERE source_url = $1 OR content_hash = $2)`
+	err := r.db.Get(&exists, query, sourceURL, contentHash)
+	return exists, err
+}
+
+// filterClause builds the WHERE clause shared by FindAll and Count
+func filterClause(opts FilterOptions) (string, []interface{}) {
+	where := ` WHERE n.deleted_at IS NULL`
+	args := []interface{}{}
+	argPos := 1
+
+	if opts.Status != "" {
+		where += fmt.Sprintf(` AND n.status = $%d`, argPos)
+		args = append(args, opts.Status)
+		argPos++
+	}
+	if opts.Category != "" {
+		where += fmt.Sprintf(` AND (
+			EXISTS (SELECT 1 FROM categories c WHERE c.id = n.category_id AND c.code = $%d)
+			OR EXISTS (SELECT 1 FROM news_tags nt JOIN tags t ON nt.tag_id = t.id WHERE nt.news_id = n.id AND t.code = $%d)
+		)`, argPos, argPos)
+		args = append(args, opts.Category)
+	}
+	return where, args
+}
+
+// FindAll retrieves news items matching the filters, newest first
+func (r *Repository) FindAll(opts FilterOptions) ([]News, error) {
+	where, args := filterClause(opts)
+	query := `SELECT ` + newsColumns + ` FROM news n` + where +
+		fmt.Sprintf(` ORDER BY n.published_at DESC NULLS LAST, n.created_at DESC LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	args = append(args, opts.Limit, opts.Offset)
+
+	items := []News{}
+	err := r.db.Select(&items, query, args...)
+	return items, err
+}
+
+// Count returns the number of news items matching the filters
+func (r *Repository) Count(opts FilterOptions) (int, error) {
+	where, args := filterClause(opts)
+	var count int
+	err := r.db.Get(&count, `SELECT COUNT(*) FROM news n`+where, args...)
+	return count, err
+}
+
+// Update modifies the content of an existing news item. The status and publish date only
+// change through SetStatus, so they are read back as stored.
+func (r *Repository) Update(id string, item *News) error {
+	query := `
+		UPDATE news
+		SET title = $1, slug = $2, summary = $3, body = $4, image_url = $5, category_id = $6, updated_at = NOW()
+		WHERE id = $7 AND deleted_at IS NULL
+		RETURNING author_id, status, published_at, created_at, updated_at
+	`
+	return r.db.QueryRow(
+		query,
+		item.Title,
+		item.Slug,
+		item.Summary,
+		item.Body,
+		item.ImageURL,
+		item.CategoryID,
+		id,
+	).Scan(&item.AuthorID, &item.Status, &item.PublishedAt, &item.CreatedAt, &item.UpdatedAt)
+}
+
+// SetStatus moves a news item from one status to another. Publishing keeps the first
+// publish date. It returns sql.ErrNoRows if the item is no longer in the expected status.
+func (r *Repository) SetStatus(id, from, to string) (*News, error) {
+	query := `
+		UPDATE news n
+		SET status = $3,
+			published_at = CASE WHEN $3 = 'PUBLISHED' THEN COALESCE(published_at, NOW()) ELSE published_at END,
+			updated_at = NOW()
+		WHERE n.id = $1 AND n.status = $2 AND n.deleted_at IS NULL
+		RETURNING ` + newsColumns
+	var item News
+	if err := r.db.Get(&item, query, id, from, to); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Delete performs soft delete
+func (r *Repository) Delete(id string) error {
+	query := `UPDATE news SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SetTags replaces the tags of a news item
+func (r *Repository) SetTags(newsID string, tagIDs []string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM news_tags WHERE news_id = $1`, newsID); err != nil {
+		return err
+	}
+	if len(tagIDs) > 0 {
+		query := `
+			INSERT INTO news_tags (news_id, tag_id)
+			SELECT $1, unnest($2::uuid[])
+			ON CONFLICT DO NOTHING
+		`
+		if _, err := tx.Exec(query, newsID, pq.Array(tagIDs)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetTags retrieves all tag codes for a news item
+func (r *Repository) GetTags(newsID string) ([]string, error) {
+	tags := []string{}
+	query := `
+		SELECT t.code
+		FROM news_tags nt
+		JOIN tags t ON nt.tag_id = t.id
+		WHERE nt.news_id = $1
+		ORDER BY t.code
+	`
+	err := r.db.Select(&tags, query, newsID)
+	return tags, err
+}
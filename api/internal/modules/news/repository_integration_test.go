@@ -0,0 +1,158 @@
+package news
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ai-dala/api/internal/testutil"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	tdb := testutil.SetupTestDatabase(t)
+	db := sqlx.NewDb(tdb.DB, "postgres")
+
+	repo := NewRepository(db)
+	service := NewService(repo)
+
+	t.Run("Create generates unique slugs", func(t *testing.T) {
+		first := &News{Title: "Hello World"}
+		require.NoError(t, service.Create(first))
+		assert.Equal(t, "hello-world", first.Slug)
+		assert.Equal(t, StatusDraft, first.Status)
+
+		second := &News{Title: "Hello World"}
+		require.NoError(t, service.Create(second))
+		assert.Equal(t, "hello-world-1", second.Slug)
+	})
+
+	t.Run("Only published news is public", func(t *testing.T) {
+		var catID string
+		require.NoError(t, db.Get(&catID, `INSERT INTO categories (code, name) VALUES ('market', '{}') RETURNING id`))
+		var tagID string
+		require.NoError(t, db.Get(&tagID, `INSERT INTO tags (code, name) VALUES ('openai', '{}') RETURNING id`))
+
+		draft := &News{Title: "Draft news", CategoryID: &catID}
+		require.NoError(t, service.Create(draft))
+		published := &News{Title: "Published news"}
+		require.NoError(t, service.Create(published))
+		require.NoError(t, service.SetTags(published.ID, []string{tagID}))
+		_, err := service.Publish(published.ID)
+		require.NoError(t, err)
+
+		items, total, err := service.ListPublished("", 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, published.ID, items[0].ID)
+		assert.NotNil(t, items[0].PublishedAt)
+
+		_, total, err = service.ListPublished("openai", 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+
+		_, total, err = service.List("", "market", 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+
+		found, err := service.GetPublishedBySlug(draft.Slug)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+
+		tags, err := service.GetTags(published.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"openai"}, tags)
+	})
+
+	t.Run("Update keeps status and Delete hides", func(t *testing.T) {
+		item := &News{Title: "Editable"}
+		require.NoError(t, service.Create(item))
+		_, err := service.Publish(item.ID)
+		require.NoError(t, err)
+
+		update := &News{Title: "Edited title", Body: "Updated body"}
+		require.NoError(t, service.Update(item.ID, update))
+		assert.Equal(t, StatusPublished, update.Status)
+		assert.Equal(t, "edited-title", update.Slug)
+
+		require.NoError(t, service.Delete(item.ID))
+		found, err := service.FindByID(item.ID)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+		assert.ErrorIs(t, service.Delete(item.ID), ErrNewsNotFound)
+	})
+
+	t.Run("Status changes only move the expected status", func(t *testing.T) {
+		item := &News{Title: "Contested"}
+		require.NoError(t, service.Create(item))
+		published, err := service.Publish(item.ID)
+		require.NoError(t, err)
+		require.NotNil(t, published.PublishedAt)
+
+		// A transition decided on a stale status does not apply
+		_, err = repo.SetStatus(item.ID, StatusDraft, StatusArchived)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		archived, err := service.Archive(item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, StatusArchived, archived.Status)
+		assert.Equal(t, published.PublishedAt.Unix(), archived.PublishedAt.Unix())
+
+		// Content saves leave the status alone
+		update := &News{Title: "Contested again"}
+		require.NoError(t, service.Update(item.ID, update))
+		assert.Equal(t, StatusArchived, update.Status)
+	})
+
+	t.Run("Ingested news is deduplicated", func(t *testing.T) {
+		sourceURL, hash := "https://example.com/story", ContentHash("Story", "Summary")
+		item := &News{Title: "Story", Summary: "Summary", SourceName: "Example", SourceURL: &sourceURL, ContentHash: &hash}
+		require.NoError(t, service.CreateIngested(item))
+		assert.Equal(t, StatusDraft, item.Status)
+
+		duplicate, err := service.IsDuplicate(sourceURL, "other-hash")
+		require.NoError(t, err)
+		assert.True(t, duplicate)
+		duplicate, err = service.IsDuplicate("https://example.com/other", hash)
+		require.NoError(t, err)
+		assert.True(t, duplicate)
+
+		again := &News{Title: "Story again", SourceURL: &sourceURL, ContentHash: &hash}
+		assert.ErrorIs(t, service.CreateIngested(again), ErrDuplicateNews)
+	})
+
+	t.Run("Concurrent imports with the same title get distinct slugs", func(t *testing.T) {
+		var wg sync.WaitGroup
+		errs := make(chan error, 5)
+		slugs := make(chan string, 5)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				sourceURL, hash := fmt.Sprintf("https://example.com/same-%d", i), ContentHash("Same headline", fmt.Sprint(i))
+				item := &News{Title: "Same headline", SourceURL: &sourceURL, ContentHash: &hash}
+				errs <- service.CreateIngested(item)
+				slugs <- item.Slug
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		close(slugs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		seen := map[string]bool{}
+		for slug := range slugs {
+			seen[slug] = true
+		}
+		assert.Len(t, seen, 5)
+	})
+}
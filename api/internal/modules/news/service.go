@@ -0,0 +1,209 @@
+package news
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ai-dala/api/internal/slugify"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// News statuses
+const (
+	StatusDraft     = "DRAFT"
+	StatusPublished = "PUBLISHED"
+	StatusArchived  = "ARCHIVED"
+)
+
+var (
+	ErrNewsNotFound  = errors.New("news not found")
+	ErrInvalidNews   = errors.New("title is required")
+	ErrDuplicateNews = errors.New("news already imported")
+	// ErrStatusConflict reports a publish or archive racing another status change
+	ErrStatusConflict = errors.New("news status changed concurrently")
+)
+
+type Service struct {
+	repo *Repository
+}
+
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Create creates a new draft news item with a unique slug
+func (s *Service) Create(item *News) error {
+	if strings.TrimSpace(item.Title) == "" {
+		return ErrInvalidNews
+	}
+	item.Status = StatusDraft
+
+	slug, err := s.uniqueSlug(item.Slug, item.Title, "")
+	if err != nil {
+		return err
+	}
+	item.Slug = slug
+
+	return s.repo.Create(item)
+}
+
+// IsDuplicate reports whether an entry with the canonical URL or content hash was already imported
+func (s *Service) IsDuplicate(sourceURL, contentHash string) (bool, error) {
+	return s.repo.IsDuplicate(sourceURL, contentHash)
+}
+
+// maxSlugAttempts bounds the retries of an import whose slug is taken concurrently
+const maxSlugAttempts = 5
+
+// CreateIngested stores an imported feed entry as a draft awaiting editor approval,
+// keeping the entry's original publication time.
+// A concurrent import of the same entry is reported as ErrDuplicateNews, and a concurrent
+// import of another entry with the same slug is retried with the next free slug.
+func (s *Service) CreateIngested(item *News) error {
+	slug := item.Slug
+	for attempt := 1; ; attempt++ {
+		item.Slug = slug
+		err := s.Create(item)
+		var pqErr *pq.Error
+		if !errors.As(err, &pqErr) || pqErr.Code != "23505" {
+			return err
+		}
+		switch {
+		case pqErr.Constraint == "idx_news_source_url" || pqErr.Constraint == "idx_news_content_hash":
+			return ErrDuplicateNews
+		case pqErr.Constraint == "news_slug_key" && attempt < maxSlugAttempts:
+			continue
+		}
+		return err
+	}
+}
+
+// FindByID retrieves a news item by ID
+func (s *Service) FindByID(id string) (*News, error) {
+	return s.repo.FindByID(id)
+}
+
+// List retrieves news items of any status for editors
+func (s *Service) List(status, category string, limit, offset int) ([]News, int, error) {
+	opts := FilterOptions{Status: status, Category: category, Limit: limit, Offset: offset}
+
+	items, err := s.repo.FindAll(opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	count, err := s.repo.Count(opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, count, nil
+}
+
+// ListPublished retrieves published news for the public site, newest first
+func (s *Service) ListPublished(category string, limit, offset int) ([]News, int, error) {
+	return s.List(StatusPublished, category, limit, offset)
+}
+
+// GetPublishedBySlug retrieves a published news item by slug, or nil if there is none
+func (s *Service) GetPublishedBySlug(slug string) (*News, error) {
+	item, err := s.repo.FindBySlug(slug)
+	if err != nil || item == nil || item.Status != StatusPublished {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Update modifies the content of a news item; the status is changed only by Publish and Archive
+func (s *Service) Update(id string, item *News) error {
+	if strings.TrimSpace(item.Title) == "" {
+		return ErrInvalidNews
+	}
+	slug, err := s.uniqueSlug(item.Slug, item.Title, id)
+	if err != nil {
+		return err
+	}
+	item.ID = id
+	item.Slug = slug
+
+	err = s.repo.Update(id, item)
+	if err == sql.ErrNoRows {
+		return ErrNewsNotFound
+	}
+	return err
+}
+
+// Publish makes a news item visible on the public site
+func (s *Service) Publish(id string) (*News, error) {
+	return s.setStatus(id, StatusPublished)
+}
+
+// Archive hides a news item from the public site
+func (s *Service) Archive(id string) (*News, error) {
+	return s.setStatus(id, StatusArchived)
+}
+
+func (s *Service) setStatus(id, status string) (*News, error) {
+	item, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrNewsNotFound
+	}
+
+	if item.Status == status {
+		return item, nil
+	}
+
+	// Only the status seen here is moved, so a concurrent change is not overwritten
+	moved, err := s.repo.SetStatus(id, item.Status, status)
+	if err == sql.ErrNoRows {
+		return nil, ErrStatusConflict
+	}
+	return moved, err
+}
+
+// Delete soft-deletes a news item
+func (s *Service) Delete(id string) error {
+	err := s.repo.Delete(id)
+	if err == sql.ErrNoRows {
+		return ErrNewsNotFound
+	}
+	return err
+}
+
+// SetTags replaces the tags of a news item
+func (s *Service) SetTags(id string, tagIDs []string) error {
+	return s.repo.SetTags(id, tagIDs)
+}
+
+// GetTags retrieves the tag codes of a news item
+func (s *Service) GetTags(id string) ([]string, error) {
+	return s.repo.GetTags(id)
+}
+
+// uniqueSlug derives a slug from the title when none is given and appends a
+// counter until it does not collide with another news item
+func (s *Service) uniqueSlug(slug, title, selfID string) (string, error) {
+	base := slugify.Make(slug)
+	if base == "" {
+		base = slugify.Make(title)
+	}
+	if base == "" {
+		// Nothing in the title transliterates, e.g. it is all punctuation or another script
+		base = "news-" + strings.SplitN(uuid.NewString(), "-", 2)[0]
+	}
+	candidate := base
+	for i := 1; ; i++ {
+		taken, err := s.repo.SlugTaken(candidate, selfID)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+}
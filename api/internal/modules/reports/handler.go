@@ -0,0 +1,157 @@
+package reports
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/ai-dala/api/internal/auth"
+)
+
+type Handler struct {
+	service  *Service
+	verifier *auth.Verifier
+}
+
+func NewHandler(service *Service, verifier *auth.Verifier) *Handler {
+	return &Handler{service: service, verifier: verifier}
+}
+
+// RegisterRoutes registers the reader route for filing reports and the editor queue routes
+func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+	mux.Handle("POST /api/reports", h.verifier.Middleware(http.HandlerFunc(h.handleCreate)))
+	mux.Handle("GET /api/reports", h.editor(h.handleQueue))
+	mux.Handle("GET /api/reports/{targetType}/{targetID}", h.editor(h.handleListForTarget))
+	mux.Handle("POST /api/reports/{targetType}/{targetID}/resolve", h.editor(h.handleResolve))
+}
+
+// editor restricts a handler to authenticated users who manage content
+func (h *Handler) editor(handler http.HandlerFunc) http.Handler {
+	return h.verifier.Middleware(auth.RequirePermission(auth.PermManageContent)(handler))
+}
+
+// writeServiceError maps service errors to HTTP status codes
+func writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidReport):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrTargetNotFound), errors.Is(err, ErrReportNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrDuplicateReport):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// handleCreate files a report.
+// Body: {"target_type": "article|comment", "target_id": "...", "reason_code": "spam", "details": "..."}
+func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		TargetType string  `json:"target_type"`
+		TargetID   string  `json:"target_id"`
+		ReasonCode string  `json:"reason_code"`
+		Details    *string `json:"details"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	report := &Report{
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		ReporterID: userID,
+		ReasonCode: req.ReasonCode,
+		Details:    req.Details,
+	}
+	if err := h.service.Create(report); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(report)
+}
+
+// handleQueue lists reported targets, most reported first.
+// Query params: status (OPEN by default, DISMISSED, RESOLVED), target_type, page, limit.
+func (h *Handler) handleQueue(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit := 20
+	if l, err := strconv.Atoi(query.Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	page := 1
+	if p, err := strconv.Atoi(query.Get("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	items, total, err := h.service.Queue(QueueOptions{
+		Status:     query.Get("status"),
+		TargetType: query.Get("target_type"),
+		Limit:      limit,
+		Offset:     (page - 1) * limit,
+	})
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"items": items,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleListForTarget lists every report filed on a target
+func (h *Handler) handleListForTarget(w http.ResponseWriter, r *http.Request) {
+	reports, err := h.service.ListForTarget(r.PathValue("targetType"), r.PathValue("targetID"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"reports": reports})
+}
+
+// handleResolve closes the open reports on a target.
+// Body: {"action": "dismiss|hide|delete"}
+func (h *Handler) handleResolve(w http.ResponseWriter, r *http.Request) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Action string `json:"action"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Resolve(r.PathValue("targetType"), r.PathValue("targetID"), req.Action, userID); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,153 @@
+package reports
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type Report struct {
+	ID         string     `db:"id" json:"id"`
+	TargetType string     `db:"target_type" json:"target_type"`
+	TargetID   string     `db:"target_id" json:"target_id"`
+	ReporterID string     `db:"reporter_id" json:"reporter_id"`
+	ReasonCode string     `db:"reason_code" json:"reason_code"`
+	Details    *string    `db:"details" json:"details,omitempty"`
+	Status     string     `db:"status" json:"status"`
+	Resolution *string    `db:"resolution" json:"resolution,omitempty"`
+	ResolvedBy *string    `db:"resolved_by" json:"resolved_by,omitempty"`
+	ResolvedAt *time.Time `db:"resolved_at" json:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+}
+
+// QueueItem groups the reports on one target for the editor queue
+type QueueItem struct {
+	TargetType      string         `db:"target_type" json:"target_type"`
+	TargetID        string         `db:"target_id" json:"target_id"`
+	ReportCount     int            `db:"report_count" json:"report_count"`
+	Reasons         pq.StringArray `db:"reasons" json:"reasons"`
+	FirstReportedAt time.Time      `db:"first_reported_at" json:"first_reported_at"`
+	LastReportedAt  time.Time      `db:"last_reported_at" json:"last_reported_at"`
+}
+
+type QueueOptions struct {
+	Status     string
+	TargetType string
+	Limit      int
+	Offset     int
+}
+
+type Repository struct {
+	db *sqlx.DB
+}
+
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const reportColumns = `id, target_type, target_id, reporter_id, reason_code, details, status, resolution, resolved_by, resolved_at, created_at`
+
+// CreateLocked inserts a report and counts the open reports on its target in one
+// transaction, holding a lock on the target until afterCount returns. Concurrent reports
+// on a target are therefore counted and acted on in turn. A second open report of the
+// same target by the same reader violates reports_open_target_reporter_key.
+func (r *Repository) CreateLocked(report *Report, afterCount func(open int) error) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2))`, report.TargetType, report.TargetID); err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO reports (target_type, target_id, reporter_id, reason_code, details)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, status, created_at
+	`
+	err = tx.QueryRowx(
+		query,
+		report.TargetType,
+		report.TargetID,
+		report.ReporterID,
+		report.ReasonCode,
+		report.Details,
+	).Scan(&report.ID, &report.Status, &report.CreatedAt)
+	if err != nil {
+		return err
+	}
+	var count int
+	query = `SELECT COUNT(*) FROM reports WHERE target_type = $1 AND target_id = $2 AND status = 'OPEN'`
+	if err := tx.Get(&count, query, report.TargetType, report.TargetID); err != nil {
+		return err
+	}
+	if err := afterCount(count); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// CountOpen counts the open reports on a target
+func (r *Repository) CountOpen(targetType, targetID string) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM reports WHERE target_type = $1 AND target_id = $2 AND status = 'OPEN'`
+	err := r.db.Get(&count, query, targetType, targetID)
+	return count, err
+}
+
+// ListQueue retrieves reported targets in a status, most reported first
+func (r *Repository) ListQueue(opts QueueOptions) ([]QueueItem, int, error) {
+	where := `WHERE status = $1`
+	args := []interface{}{opts.Status}
+	if opts.TargetType != "" {
+		where += ` AND target_type = $2`
+		args = append(args, opts.TargetType)
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(DISTINCT (target_type, target_id)) FROM reports ` + where
+	if err := r.db.Get(&total, countQuery, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT target_type, target_id, COUNT(*) AS report_count,
+			ARRAY_AGG(DISTINCT reason_code) AS reasons,
+			MIN(created_at) AS first_reported_at, MAX(created_at) AS last_reported_at
+		FROM reports
+		%s
+		GROUP BY target_type, target_id
+		ORDER BY report_count DESC, first_reported_at ASC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+	args = append(args, opts.Limit, opts.Offset)
+
+	items := []QueueItem{}
+	err := r.db.Select(&items, query, args...)
+	return items, total, err
+}
+
+// ListForTarget retrieves every report on a target, oldest first
+func (r *Repository) ListForTarget(targetType, targetID string) ([]Report, error) {
+	query := `SELECT ` + reportColumns + ` FROM reports WHERE target_type = $1 AND target_id = $2 ORDER BY created_at ASC`
+	reports := []Report{}
+	err := r.db.Select(&reports, query, targetType, targetID)
+	return reports, err
+}
+
+// Resolve closes the open reports on a target and returns how many were closed
+func (r *Repository) Resolve(targetType, targetID, status, resolution, resolverID string) (int64, error) {
+	query := `
+		UPDATE reports
+		SET status = $3, resolution = $4, resolved_by = $5, resolved_at = NOW()
+		WHERE target_type = $1 AND target_id = $2 AND status = 'OPEN'
+	`
+	result, err := r.db.Exec(query, targetType, targetID, status, resolution, resolverID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
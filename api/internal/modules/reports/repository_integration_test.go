@@ -0,0 +1,122 @@
+package reports
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ai-dala/api/internal/testutil"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	tdb := testutil.SetupTestDatabase(t)
+	db := sqlx.NewDb(tdb.DB, "postgres")
+
+	repo := NewRepository(db)
+	comments := newFakeTarget(testTargetID)
+	service := NewService(repo, map[string]Target{TargetComment: comments}, Config{AutoHideThreshold: 2})
+
+	editorID := "00000000-0000-0000-0000-000000000009"
+	report := func(reporterID string) *Report {
+		return &Report{TargetType: TargetComment, TargetID: testTargetID, ReporterID: reporterID, ReasonCode: "spam"}
+	}
+
+	t.Run("Readers report a target once", func(t *testing.T) {
+		require.NoError(t, service.Create(report("00000000-0000-0000-0000-000000000001")))
+		assert.ErrorIs(t, service.Create(report("00000000-0000-0000-0000-000000000001")), ErrDuplicateReport)
+		assert.True(t, comments.visible[testTargetID])
+	})
+
+	t.Run("Threshold hides the target", func(t *testing.T) {
+		require.NoError(t, service.Create(report("00000000-0000-0000-0000-000000000002")))
+		assert.False(t, comments.visible[testTargetID])
+		assert.Equal(t, "hidden after 2 reports", comments.hidden[testTargetID])
+	})
+
+	t.Run("Queue groups reports by target", func(t *testing.T) {
+		items, total, err := service.Queue(QueueOptions{Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, items, 1)
+		assert.Equal(t, 2, items[0].ReportCount)
+		assert.Equal(t, []string{"spam"}, []string(items[0].Reasons))
+	})
+
+	t.Run("Dismiss restores the target and closes reports", func(t *testing.T) {
+		require.NoError(t, service.Resolve(TargetComment, testTargetID, ActionDismiss, editorID))
+		assert.True(t, comments.visible[testTargetID])
+
+		reports, err := service.ListForTarget(TargetComment, testTargetID)
+		require.NoError(t, err)
+		for _, r := range reports {
+			assert.Equal(t, StatusDismissed, r.Status)
+		}
+
+		assert.ErrorIs(t, service.Resolve(TargetComment, testTargetID, ActionDelete, editorID), ErrReportNotFound)
+	})
+
+	t.Run("Readers can report again after a dismissal", func(t *testing.T) {
+		require.NoError(t, service.Create(report("00000000-0000-0000-0000-000000000001")))
+		assert.ErrorIs(t, service.Create(report("00000000-0000-0000-0000-000000000001")), ErrDuplicateReport)
+	})
+
+	t.Run("Concurrent reports are counted in turn", func(t *testing.T) {
+		targetID := "33333333-3333-3333-3333-333333333333"
+		target := &lockedTarget{}
+		service := NewService(repo, map[string]Target{TargetArticle: target}, Config{AutoHideThreshold: 3})
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 6)
+		for i := 1; i <= 6; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				reporterID := fmt.Sprintf("00000000-0000-0000-0000-00000000001%d", i)
+				errs <- service.Create(&Report{TargetType: TargetArticle, TargetID: targetID, ReporterID: reporterID, ReasonCode: "spam"})
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		// Every report sees its own count, so the threshold is crossed exactly once
+		require.Len(t, target.hides, 4)
+		assert.Equal(t, "hidden after 3 reports", target.hides[0])
+		assert.Equal(t, "hidden after 6 reports", target.hides[3])
+	})
+}
+
+// lockedTarget is a Target safe for concurrent reports that records every hide in order
+type lockedTarget struct {
+	mu    sync.Mutex
+	hides []string
+}
+
+func (l *lockedTarget) Exists(id string) (bool, error) {
+	return true, nil
+}
+
+func (l *lockedTarget) Hide(id, actorID, reason string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hides = append(l.hides, reason)
+	return nil
+}
+
+func (l *lockedTarget) Restore(id, actorID string) error {
+	return nil
+}
+
+func (l *lockedTarget) Delete(id, actorID string) error {
+	return nil
+}
@@ -0,0 +1,214 @@
+package reports
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Target types that can be reported
+const (
+	TargetArticle = "article"
+	TargetComment = "comment"
+)
+
+// Report statuses
+const (
+	StatusOpen      = "OPEN"
+	StatusDismissed = "DISMISSED"
+	StatusResolved  = "RESOLVED"
+)
+
+// Resolution actions an editor can take on a reported target
+const (
+	ActionDismiss = "dismiss"
+	ActionHide    = "hide"
+	ActionDelete  = "delete"
+)
+
+// ReasonCodes lists the accepted report reasons
+var ReasonCodes = []string{"spam", "abuse", "harassment", "misinformation", "off_topic", "other"}
+
+// maxDetailsLength bounds the free-text part of a report
+const maxDetailsLength = 2000
+
+var (
+	ErrInvalidReport   = errors.New("invalid report")
+	ErrTargetNotFound  = errors.New("report target not found")
+	ErrDuplicateReport = errors.New("already reported")
+	ErrReportNotFound  = errors.New("no open reports for target")
+)
+
+// Target is something readers can report. Hide, Restore and Delete are no-ops when the
+// target is already in the requested state; an empty actorID means an automatic action.
+// Restore only undoes an automatic Hide, never a hide by an editor or another process.
+type Target interface {
+	// Exists reports whether the target is visible to readers
+	Exists(id string) (bool, error)
+	Hide(id, actorID, reason string) error
+	Restore(id, actorID string) error
+	Delete(id, actorID string) error
+}
+
+// Config tunes the reports service
+type Config struct {
+	// AutoHideThreshold is the number of open reports that hides a target until an editor
+	// looks at it; zero disables automatic hiding
+	AutoHideThreshold int
+}
+
+// ConfigFromEnv reads REPORTS_AUTO_HIDE_THRESHOLD, defaulting to 5
+func ConfigFromEnv() Config {
+	cfg := Config{AutoHideThreshold: 5}
+	if v := os.Getenv("REPORTS_AUTO_HIDE_THRESHOLD"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.AutoHideThreshold = n
+		}
+	}
+	return cfg
+}
+
+type Service struct {
+	repo    *Repository
+	targets map[string]Target
+	config  Config
+}
+
+// NewService creates the reports service for the given target types
+func NewService(repo *Repository, targets map[string]Target, config Config) *Service {
+	return &Service{repo: repo, targets: targets, config: config}
+}
+
+// Create files a report on behalf of a reader. Each reader can have one open report per target.
+// Once a target collects AutoHideThreshold open reports it is hidden.
+func (s *Service) Create(report *Report) error {
+	target, ok := s.targets[report.TargetType]
+	if !ok {
+		return fmt.Errorf("%w: unknown target type %q", ErrInvalidReport, report.TargetType)
+	}
+	if _, err := uuid.Parse(report.TargetID); err != nil {
+		return ErrTargetNotFound
+	}
+	if !isReasonCode(report.ReasonCode) {
+		return fmt.Errorf("%w: reason_code must be one of %s", ErrInvalidReport, strings.Join(ReasonCodes, ", "))
+	}
+	if report.Details != nil {
+		details := strings.TrimSpace(*report.Details)
+		if len(details) > maxDetailsLength {
+			return fmt.Errorf("%w: details are limited to %d characters", ErrInvalidReport, maxDetailsLength)
+		}
+		if details == "" {
+			report.Details = nil
+		} else {
+			report.Details = &details
+		}
+	}
+
+	exists, err := target.Exists(report.TargetID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrTargetNotFound
+	}
+
+	// The count and the hide happen under the target lock, so concurrent reports that
+	// cross the threshold hide the target exactly once
+	err = s.repo.CreateLocked(report, func(count int) error {
+		if s.config.AutoHideThreshold <= 0 || count < s.config.AutoHideThreshold {
+			return nil
+		}
+		reason := fmt.Sprintf("hidden after %d reports", count)
+		if err := target.Hide(report.TargetID, "", reason); err != nil {
+			return err
+		}
+		log.Printf("[REPORTS] %s %s %s", report.TargetType, report.TargetID, reason)
+		return nil
+	})
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "reports_open_target_reporter_key" {
+		return ErrDuplicateReport
+	}
+	return err
+}
+
+// Queue lists reported targets, open ones by default
+func (s *Service) Queue(opts QueueOptions) ([]QueueItem, int, error) {
+	if opts.Status == "" {
+		opts.Status = StatusOpen
+	}
+	if opts.Status != StatusOpen && opts.Status != StatusDismissed && opts.Status != StatusResolved {
+		return nil, 0, fmt.Errorf("%w: unknown status %q", ErrInvalidReport, opts.Status)
+	}
+	if opts.TargetType != "" {
+		if _, ok := s.targets[opts.TargetType]; !ok {
+			return nil, 0, fmt.Errorf("%w: unknown target type %q", ErrInvalidReport, opts.TargetType)
+		}
+	}
+	return s.repo.ListQueue(opts)
+}
+
+// ListForTarget retrieves every report filed on a target
+func (s *Service) ListForTarget(targetType, targetID string) ([]Report, error) {
+	if _, ok := s.targets[targetType]; !ok {
+		return nil, fmt.Errorf("%w: unknown target type %q", ErrInvalidReport, targetType)
+	}
+	if _, err := uuid.Parse(targetID); err != nil {
+		return []Report{}, nil
+	}
+	return s.repo.ListForTarget(targetType, targetID)
+}
+
+// Resolve closes the open reports on a target with an editor action:
+// dismiss restores a target the report threshold hid, hide hides it and delete removes it
+func (s *Service) Resolve(targetType, targetID, action, editorID string) error {
+	target, ok := s.targets[targetType]
+	if !ok {
+		return fmt.Errorf("%w: unknown target type %q", ErrInvalidReport, targetType)
+	}
+	if _, err := uuid.Parse(targetID); err != nil {
+		return ErrReportNotFound
+	}
+
+	count, err := s.repo.CountOpen(targetType, targetID)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrReportNotFound
+	}
+
+	status := StatusResolved
+	switch action {
+	case ActionDismiss:
+		status = StatusDismissed
+		err = target.Restore(targetID, editorID)
+	case ActionHide:
+		err = target.Hide(targetID, editorID, "hidden by editor after reports")
+	case ActionDelete:
+		err = target.Delete(targetID, editorID)
+	default:
+		return fmt.Errorf("%w: action must be dismiss, hide or delete", ErrInvalidReport)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = s.repo.Resolve(targetType, targetID, status, action, editorID)
+	return err
+}
+
+func isReasonCode(code string) bool {
+	for _, c := range ReasonCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package reports
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTarget is an in-memory Target that records what happened to each ID
+type fakeTarget struct {
+	visible  map[string]bool
+	hidden   map[string]string
+	restored []string
+	deleted  []string
+}
+
+func newFakeTarget(ids ...string) *fakeTarget {
+	f := &fakeTarget{visible: map[string]bool{}, hidden: map[string]string{}}
+	for _, id := range ids {
+		f.visible[id] = true
+	}
+	return f
+}
+
+func (f *fakeTarget) Exists(id string) (bool, error) {
+	return f.visible[id], nil
+}
+
+func (f *fakeTarget) Hide(id, actorID, reason string) error {
+	f.visible[id] = false
+	f.hidden[id] = reason
+	return nil
+}
+
+func (f *fakeTarget) Restore(id, actorID string) error {
+	f.visible[id] = true
+	f.restored = append(f.restored, id)
+	return nil
+}
+
+func (f *fakeTarget) Delete(id, actorID string) error {
+	f.visible[id] = false
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+const testTargetID = "11111111-1111-1111-1111-111111111111"
+
+func TestService_Create_Validation(t *testing.T) {
+	svc := NewService(nil, map[string]Target{TargetComment: newFakeTarget(testTargetID)}, Config{})
+	long := strings.Repeat("x", maxDetailsLength+1)
+
+	tests := []struct {
+		name   string
+		report Report
+		err    error
+	}{
+		{"Unknown target type", Report{TargetType: "news", TargetID: testTargetID, ReasonCode: "spam"}, ErrInvalidReport},
+		{"Malformed target ID", Report{TargetType: TargetComment, TargetID: "42", ReasonCode: "spam"}, ErrTargetNotFound},
+		{"Unknown reason", Report{TargetType: TargetComment, TargetID: testTargetID, ReasonCode: "boring"}, ErrInvalidReport},
+		{"Details too long", Report{TargetType: TargetComment, TargetID: testTargetID, ReasonCode: "other", Details: &long}, ErrInvalidReport},
+		{"Hidden target", Report{TargetType: TargetComment, TargetID: "22222222-2222-2222-2222-222222222222", ReasonCode: "spam"}, ErrTargetNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.Create(&tt.report)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestService_Queue_Validation(t *testing.T) {
+	svc := NewService(nil, map[string]Target{TargetComment: newFakeTarget()}, Config{})
+
+	_, _, err := svc.Queue(QueueOptions{Status: "CLOSED"})
+	assert.ErrorIs(t, err, ErrInvalidReport)
+
+	_, _, err = svc.Queue(QueueOptions{TargetType: "news"})
+	assert.ErrorIs(t, err, ErrInvalidReport)
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("REPORTS_AUTO_HIDE_THRESHOLD", "")
+	assert.Equal(t, 5, ConfigFromEnv().AutoHideThreshold)
+
+	t.Setenv("REPORTS_AUTO_HIDE_THRESHOLD", "0")
+	assert.Equal(t, 0, ConfigFromEnv().AutoHideThreshold)
+}
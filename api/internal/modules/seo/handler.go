@@ -0,0 +1,169 @@
+package seo
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RobotsConfig controls the generated robots.txt
+type RobotsConfig struct {
+	// DisallowAll blocks every crawler, e.g. on staging
+	DisallowAll bool
+	Allow       []string
+	Disallow    []string
+}
+
+// RobotsConfigFromEnv reads ROBOTS_DISALLOW_ALL and the comma-separated
+// ROBOTS_ALLOW and ROBOTS_DISALLOW path lists. Private areas of the site are
+// disallowed unless ROBOTS_DISALLOW is set.
+func RobotsConfigFromEnv() RobotsConfig {
+	disallow := os.Getenv("ROBOTS_DISALLOW")
+	if disallow == "" {
+		disallow = "/dashboard,/login,/api/"
+	}
+	all, _ := strconv.ParseBool(os.Getenv("ROBOTS_DISALLOW_ALL"))
+	return RobotsConfig{
+		DisallowAll: all,
+		Allow:       splitPaths(os.Getenv("ROBOTS_ALLOW")),
+		Disallow:    splitPaths(disallow),
+	}
+}
+
+func splitPaths(value string) []string {
+	var paths []string
+	for _, p := range strings.Split(value, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+type Handler struct {
+	service Service
+	robots  RobotsConfig
+	siteURL string
+}
+
+func NewHandler(service Service, robots RobotsConfig, siteURL string) *Handler {
+	return &Handler{service: service, robots: robots, siteURL: strings.TrimRight(siteURL, "/")}
+}
+
+// RegisterRoutes registers robots.txt and the sitemaps. The web site is expected
+// to proxy these paths so that the URLs in the index resolve on the public host.
+func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /robots.txt", h.handleRobots)
+	mux.HandleFunc("GET /sitemap.xml", h.handleIndex)
+	mux.HandleFunc("GET /sitemaps/{file}", h.handleSitemap)
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name         `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+	Sitemaps []sitemapElement `xml:"sitemap"`
+}
+
+type sitemapElement struct {
+	Loc string `xml:"loc"`
+}
+
+type urlSet struct {
+	XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []urlElement `xml:"url"`
+}
+
+type urlElement struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+func (h *Handler) handleIndex(w http.ResponseWriter, r *http.Request) {
+	refs, err := h.service.Index(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	index := sitemapIndex{Sitemaps: make([]sitemapElement, 0, len(refs))}
+	for _, ref := range refs {
+		index.Sitemaps = append(index.Sitemaps, sitemapElement{Loc: ref.Loc})
+	}
+	writeXML(w, index)
+}
+
+// handleSitemap serves /sitemaps/{section}-{page}.xml
+func (h *Handler) handleSitemap(w http.ResponseWriter, r *http.Request) {
+	name, ok := strings.CutSuffix(r.PathValue("file"), ".xml")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	dash := strings.LastIndex(name, "-")
+	if dash < 0 {
+		http.NotFound(w, r)
+		return
+	}
+	page, err := strconv.Atoi(name[dash+1:])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	urls, err := h.service.Sitemap(r.Context(), name[:dash], page)
+	if errors.Is(err, ErrSitemapNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	set := urlSet{URLs: make([]urlElement, 0, len(urls))}
+	for _, u := range urls {
+		el := urlElement{Loc: u.Loc}
+		if !u.LastMod.IsZero() {
+			el.LastMod = u.LastMod.UTC().Format(time.RFC3339)
+		}
+		set.URLs = append(set.URLs, el)
+	}
+	writeXML(w, set)
+}
+
+func (h *Handler) handleRobots(w http.ResponseWriter, r *http.Request) {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	if h.robots.DisallowAll {
+		b.WriteString("Disallow: /\n")
+	} else {
+		for _, path := range h.robots.Allow {
+			fmt.Fprintf(&b, "Allow: %s\n", path)
+		}
+		for _, path := range h.robots.Disallow {
+			fmt.Fprintf(&b, "Disallow: %s\n", path)
+		}
+		if len(h.robots.Allow) == 0 && len(h.robots.Disallow) == 0 {
+			b.WriteString("Disallow:\n")
+		}
+	}
+	fmt.Fprintf(&b, "\nSitemap: %s/sitemap.xml\n", h.siteURL)
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte(b.String()))
+}
+
+func writeXML(w http.ResponseWriter, doc any) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Write([]byte(xml.Header))
+	w.Write(body)
+}
@@ -0,0 +1,79 @@
+package seo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestMux(robots RobotsConfig) *http.ServeMux {
+	mux := http.NewServeMux()
+	NewHandler(newTestService(2), robots, "https://ai-dala.example/").RegisterRoutes(mux)
+	return mux
+}
+
+func get(mux *http.ServeMux, path string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+	return rr
+}
+
+func TestHandler_Sitemaps(t *testing.T) {
+	mux := newTestMux(RobotsConfig{})
+
+	rr := get(mux, "/sitemap.xml")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	body := rr.Body.String()
+	if !strings.Contains(body, `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`) ||
+		!strings.Contains(body, "<loc>https://ai-dala.example/sitemaps/articles-3.xml</loc>") {
+		t.Errorf("unexpected index:\n%s", body)
+	}
+
+	rr = get(mux, "/sitemaps/articles-1.xml")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	body = rr.Body.String()
+	if strings.Count(body, "<url>") != 2 || !strings.Contains(body, "<lastmod>2025-11-20T10:00:00Z</lastmod>") {
+		t.Errorf("unexpected sitemap:\n%s", body)
+	}
+
+	for _, path := range []string{"/sitemaps/articles-9.xml", "/sitemaps/articles.xml", "/sitemaps/articles-1.txt", "/sitemaps/users-1.xml"} {
+		if rr := get(mux, path); rr.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d", path, rr.Code)
+		}
+	}
+}
+
+func TestHandler_Robots(t *testing.T) {
+	rr := get(newTestMux(RobotsConfig{Allow: []string{"/articles"}, Disallow: []string{"/dashboard"}}), "/robots.txt")
+	want := "User-agent: *\nAllow: /articles\nDisallow: /dashboard\n\nSitemap: https://ai-dala.example/sitemap.xml\n"
+	if rr.Body.String() != want {
+		t.Errorf("unexpected robots.txt:\n%s", rr.Body.String())
+	}
+
+	rr = get(newTestMux(RobotsConfig{DisallowAll: true, Disallow: []string{"/dashboard"}}), "/robots.txt")
+	if !strings.HasPrefix(rr.Body.String(), "User-agent: *\nDisallow: /\n") || strings.Contains(rr.Body.String(), "/dashboard") {
+		t.Errorf("unexpected robots.txt:\n%s", rr.Body.String())
+	}
+}
+
+func TestRobotsConfigFromEnv(t *testing.T) {
+	t.Setenv("ROBOTS_DISALLOW", "")
+	t.Setenv("ROBOTS_ALLOW", "")
+	t.Setenv("ROBOTS_DISALLOW_ALL", "")
+	cfg := RobotsConfigFromEnv()
+	if strings.Join(cfg.Disallow, ",") != "/dashboard,/login,/api/" || cfg.DisallowAll {
+		t.Errorf("unexpected defaults %+v", cfg)
+	}
+
+	t.Setenv("ROBOTS_DISALLOW", " /private , /tmp")
+	t.Setenv("ROBOTS_DISALLOW_ALL", "true")
+	cfg = RobotsConfigFromEnv()
+	if strings.Join(cfg.Disallow, ",") != "/private,/tmp" || !cfg.DisallowAll {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+}
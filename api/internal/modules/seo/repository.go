@@ -0,0 +1,80 @@
+package seo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Sitemap sections, one family of sitemap files each
+const (
+	SectionArticles   = "articles"
+	SectionNews       = "news"
+	SectionCategories = "categories"
+	SectionTags       = "tags"
+)
+
+// Sections lists the sitemap sections in the order they appear in the index
+var Sections = []string{SectionArticles, SectionNews, SectionCategories, SectionTags}
+
+// Entry is a public page of a section: the key identifying it in its URL and when it last changed
+type Entry struct {
+	Key     string    `db:"key"`
+	LastMod time.Time `db:"lastmod"`
+}
+
+type Repository interface {
+	Count(ctx context.Context, section string) (int, error)
+	List(ctx context.Context, section string, limit, offset int) ([]Entry, error)
+}
+
+// sectionSources select the key and lastmod of every public row, leaving out
+// soft-deleted rows and anything that is not PUBLISHED (drafts, ARCHIVED, ...)
+var sectionSources = map[string]string{
+	SectionArticles: `
+		SELECT slug AS key, COALESCE(updated_at, created_at) AS lastmod, id
+		FROM articles
+		WHERE status = 'PUBLISHED' AND deleted_at IS NULL`,
+	SectionNews: `
+		SELECT slug AS key, COALESCE(updated_at, created_at) AS lastmod, id
+		FROM news
+		WHERE status = 'PUBLISHED' AND deleted_at IS NULL`,
+	SectionCategories: `
+		SELECT id::text AS key, COALESCE(updated_at, created_at) AS lastmod, id
+		FROM categories
+		WHERE deleted_at IS NULL`,
+	SectionTags: `
+		SELECT code AS key, COALESCE(updated_at, created_at) AS lastmod, id
+		FROM tags`,
+}
+
+type postgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewRepository(db *sqlx.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+func (r *postgresRepository) Count(ctx context.Context, section string) (int, error) {
+	source, ok := sectionSources[section]
+	if !ok {
+		return 0, fmt.Errorf("unknown sitemap section %q", section)
+	}
+	var count int
+	err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM (`+source+`) s`)
+	return count, err
+}
+
+func (r *postgresRepository) List(ctx context.Context, section string, limit, offset int) ([]Entry, error) {
+	source, ok := sectionSources[section]
+	if !ok {
+		return nil, fmt.Errorf("unknown sitemap section %q", section)
+	}
+	entries := []Entry{}
+	query := `SELECT key, lastmod FROM (` + source + `) s ORDER BY id LIMIT $1 OFFSET $2`
+	err := r.db.SelectContext(ctx, &entries, query, limit, offset)
+	return entries, err
+}
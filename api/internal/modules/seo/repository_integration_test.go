@@ -0,0 +1,64 @@
+package seo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ai-dala/api/internal/testutil"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	testDB := testutil.SetupTestDatabase(t)
+	db := sqlx.NewDb(testDB.DB, "postgres")
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	author := "123e4567-e89b-12d3-a456-426614174000"
+	for _, row := range []struct {
+		slug, status string
+		deleted      bool
+	}{
+		{"published", "PUBLISHED", false},
+		{"archived", "ARCHIVED", false},
+		{"draft", "DRAFT", false},
+		{"deleted", "PUBLISHED", true},
+	} {
+		_, err := db.Exec(`
+			INSERT INTO articles (title, slug, body, author_id, status, deleted_at)
+			VALUES ($1, $1, '', $2, $3, CASE WHEN $4 THEN NOW() END)`,
+			row.slug, author, row.status, row.deleted)
+		require.NoError(t, err)
+	}
+	_, err := db.Exec(`INSERT INTO categories (code, name, deleted_at) VALUES ('live', '{}', NULL), ('gone', '{}', NOW())`)
+	require.NoError(t, err)
+
+	t.Run("Only published, non-deleted articles", func(t *testing.T) {
+		count, err := repo.Count(ctx, SectionArticles)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		entries, err := repo.List(ctx, SectionArticles, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "published", entries[0].Key)
+		assert.False(t, entries[0].LastMod.IsZero())
+	})
+
+	t.Run("Soft-deleted categories are skipped", func(t *testing.T) {
+		count, err := repo.Count(ctx, SectionCategories)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("Unknown section", func(t *testing.T) {
+		_, err := repo.Count(ctx, "users")
+		assert.Error(t, err)
+	})
+}
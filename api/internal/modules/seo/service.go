@@ -0,0 +1,93 @@
+package seo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MaxURLsPerSitemap is the sitemap protocol limit on URLs in one file
+const MaxURLsPerSitemap = 50000
+
+var ErrSitemapNotFound = errors.New("sitemap not found")
+
+// SitemapRef is an entry of the sitemap index
+type SitemapRef struct {
+	Loc string
+}
+
+// URL is an entry of a sitemap file
+type URL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+type Service interface {
+	// Index lists every sitemap file, splitting each section into pages of at most MaxURLsPerSitemap URLs
+	Index(ctx context.Context) ([]SitemapRef, error)
+	// Sitemap returns the URLs of one page (starting at 1) of a section
+	Sitemap(ctx context.Context, section string, page int) ([]URL, error)
+}
+
+type service struct {
+	repo     Repository
+	siteURL  string
+	pageSize int
+}
+
+// NewService builds sitemaps with absolute URLs on the public site
+func NewService(repo Repository, siteURL string) Service {
+	return &service{repo: repo, siteURL: strings.TrimRight(siteURL, "/"), pageSize: MaxURLsPerSitemap}
+}
+
+func (s *service) Index(ctx context.Context) ([]SitemapRef, error) {
+	var refs []SitemapRef
+	for _, section := range Sections {
+		count, err := s.repo.Count(ctx, section)
+		if err != nil {
+			return nil, err
+		}
+		pages := (count + s.pageSize - 1) / s.pageSize
+		for page := 1; page <= pages; page++ {
+			refs = append(refs, SitemapRef{Loc: fmt.Sprintf("%s/sitemaps/%s-%d.xml", s.siteURL, section, page)})
+		}
+	}
+	return refs, nil
+}
+
+func (s *service) Sitemap(ctx context.Context, section string, page int) ([]URL, error) {
+	if _, ok := sectionSources[section]; !ok || page < 1 {
+		return nil, ErrSitemapNotFound
+	}
+
+	entries, err := s.repo.List(ctx, section, s.pageSize, (page-1)*s.pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrSitemapNotFound
+	}
+
+	urls := make([]URL, 0, len(entries))
+	for _, e := range entries {
+		urls = append(urls, URL{Loc: s.siteURL + pagePath(section, e.Key), LastMod: e.LastMod})
+	}
+	return urls, nil
+}
+
+// pagePath returns the path of the public page listing an entry of a section
+func pagePath(section, key string) string {
+	switch section {
+	case SectionArticles:
+		return "/articles/" + url.PathEscape(key)
+	case SectionNews:
+		return "/news/" + url.PathEscape(key)
+	case SectionCategories:
+		return "/articles?category_id=" + url.QueryEscape(key)
+	default:
+		return "/articles?tags=" + url.QueryEscape(key)
+	}
+}
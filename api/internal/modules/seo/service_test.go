@@ -0,0 +1,100 @@
+package seo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type mockRepo struct {
+	entries map[string][]Entry
+}
+
+func (m *mockRepo) Count(ctx context.Context, section string) (int, error) {
+	return len(m.entries[section]), nil
+}
+
+func (m *mockRepo) List(ctx context.Context, section string, limit, offset int) ([]Entry, error) {
+	all := m.entries[section]
+	if offset >= len(all) {
+		return []Entry{}, nil
+	}
+	end := offset + limit
+	if end > len(all) {
+		end = len(all)
+	}
+	return all[offset:end], nil
+}
+
+func newTestService(pageSize int) *service {
+	lastMod := time.Date(2025, 11, 20, 10, 0, 0, 0, time.UTC)
+	repo := &mockRepo{entries: map[string][]Entry{}}
+	for i := 0; i < 5; i++ {
+		repo.entries[SectionArticles] = append(repo.entries[SectionArticles], Entry{Key: fmt.Sprintf("article-%d", i), LastMod: lastMod})
+	}
+	repo.entries[SectionTags] = []Entry{{Key: "ai & ml", LastMod: lastMod}}
+	return &service{repo: repo, siteURL: "https://ai-dala.example", pageSize: pageSize}
+}
+
+func TestService_Index(t *testing.T) {
+	refs, err := newTestService(2).Index(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		"https://ai-dala.example/sitemaps/articles-1.xml",
+		"https://ai-dala.example/sitemaps/articles-2.xml",
+		"https://ai-dala.example/sitemaps/articles-3.xml",
+		"https://ai-dala.example/sitemaps/tags-1.xml",
+	}
+	if len(refs) != len(want) {
+		t.Fatalf("expected %d sitemaps, got %d: %v", len(want), len(refs), refs)
+	}
+	for i, ref := range refs {
+		if ref.Loc != want[i] {
+			t.Errorf("sitemap %d: expected %s, got %s", i, want[i], ref.Loc)
+		}
+	}
+}
+
+func TestService_Sitemap(t *testing.T) {
+	svc := newTestService(2)
+	ctx := context.Background()
+
+	urls, err := svc.Sitemap(ctx, SectionArticles, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(urls) != 1 || urls[0].Loc != "https://ai-dala.example/articles/article-4" {
+		t.Errorf("unexpected last page %v", urls)
+	}
+
+	urls, err = svc.Sitemap(ctx, SectionTags, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if urls[0].Loc != "https://ai-dala.example/articles?tags=ai+%26+ml" {
+		t.Errorf("expected escaped tag url, got %s", urls[0].Loc)
+	}
+
+	for _, tc := range []struct {
+		section string
+		page    int
+	}{{SectionArticles, 4}, {SectionArticles, 0}, {"users", 1}, {SectionNews, 1}} {
+		if _, err := svc.Sitemap(ctx, tc.section, tc.page); err != ErrSitemapNotFound {
+			t.Errorf("%s-%d: expected ErrSitemapNotFound, got %v", tc.section, tc.page, err)
+		}
+	}
+}
+
+func TestNewService_UsesProtocolLimit(t *testing.T) {
+	svc := NewService(&mockRepo{}, "https://ai-dala.example/").(*service)
+	if svc.pageSize != MaxURLsPerSitemap {
+		t.Errorf("expected page size %d, got %d", MaxURLsPerSitemap, svc.pageSize)
+	}
+	if svc.siteURL != "https://ai-dala.example" {
+		t.Errorf("expected trailing slash to be trimmed, got %s", svc.siteURL)
+	}
+}
@@ -8,16 +8,17 @@ import (
 )
 
 type Handler struct {
-	service *Service
+	service  *Service
+	verifier *auth.Verifier
 }
 
-func NewHandler(service *Service) *Handler {
-	return &Handler{service: service}
+func NewHandler(service *Service, verifier *auth.Verifier) *Handler {
+	return &Handler{service: service, verifier: verifier}
 }
 
 // RegisterRoutes registers user routes
 func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
-	mux.Handle("GET /api/user/activity", auth.Middleware(http.HandlerFunc(h.handleGetActivity)))
+	mux.Handle("GET /api/user/activity", h.verifier.Middleware(http.HandlerFunc(h.handleGetActivity)))
 }
 
 // handleGetActivity retrieves user's recent activity
@@ -54,7 +54,7 @@ func TestHandler_GetUserActivity(t *testing.T) {
 	// Setup service and handler
 	repo := NewRepository(db)
 	service := NewService(repo)
-	handler := NewHandler(service)
+	handler := NewHandler(service, nil)
 
 	// Create request with authenticated context
 	req := httptest.NewRequest("GET", "/api/user/activity", nil)
@@ -98,7 +98,7 @@ func TestHandler_GetUserActivity_Unauthorized(t *testing.T) {
 	db := sqlx.NewDb(testDB.DB, "postgres")
 	repo := NewRepository(db)
 	service := NewService(repo)
-	handler := NewHandler(service)
+	handler := NewHandler(service, nil)
 
 	// Create request without authenticated context
 	req := httptest.NewRequest("GET", "/api/user/activity", nil)
@@ -125,7 +125,7 @@ func TestHandler_GetUserActivity_NoActivity(t *testing.T) {
 	// Setup service and handler
 	repo := NewRepository(db)
 	service := NewService(repo)
-	handler := NewHandler(service)
+	handler := NewHandler(service, nil)
 
 	// Create request with authenticated context
 	req := httptest.NewRequest("GET", "/api/user/activity", nil)
@@ -148,4 +148,5 @@ func TestHandler_GetUserActivity_NoActivity(t *testing.T) {
 	// Assert empty activity
 	assert.Len(t, activity.Likes, 0)
 	assert.Len(t, activity.Comments, 0)
+	assert.Len(t, activity.Bookmarks, 0)
 }
@@ -8,23 +8,30 @@ import (
 )
 
 type UserActivity struct {
-	Likes    []UserLikeActivity    `json:"likes"`
-	Comments []UserCommentActivity `json:"comments"`
+	Likes     []UserLikeActivity     `json:"likes"`
+	Comments  []UserCommentActivity  `json:"comments"`
+	Bookmarks []UserBookmarkActivity `json:"bookmarks"`
 }
 
 type UserLikeActivity struct {
-	ArticleID    string    `json:"article_id"`
-	ArticleTitle string    `json:"article_title"`
-	IsLike       bool      `json:"is_like"`
-	CreatedAt    time.Time `json:"created_at"`
+	ArticleID    string    `db:"article_id" json:"article_id"`
+	ArticleTitle string    `db:"article_title" json:"article_title"`
+	IsLike       bool      `db:"is_like" json:"is_like"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
 }
 
 type UserCommentActivity struct {
-	ID           string    `json:"id"`
-	ArticleID    string    `json:"article_id"`
-	ArticleTitle string    `json:"article_title"`
-	Body         string    `json:"body"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID           string    `db:"id" json:"id"`
+	ArticleID    string    `db:"article_id" json:"article_id"`
+	ArticleTitle string    `db:"article_title" json:"article_title"`
+	Body         string    `db:"body" json:"body"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+}
+
+type UserBookmarkActivity struct {
+	ArticleID    string    `db:"article_id" json:"article_id"`
+	ArticleTitle string    `db:"article_title" json:"article_title"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
 }
 
 type Repository struct {
@@ -35,7 +42,7 @@ func NewRepository(db *sqlx.DB) *Repository {
 	return &Repository{db: db}
 }
 
-// GetUserActivity retrieves user's recent likes and comments (last 30 days, max 10 each)
+// GetUserActivity retrieves user's recent likes, comments and bookmarks (last 30 days, max 10 each)
 func (r *Repository) GetUserActivity(userID string) (*UserActivity, error) {
 	activity := &UserActivity{}
 
@@ -64,6 +71,7 @@ func (r *Repository) GetUserActivity(userID string) (*UserActivity, error) {
 		FROM comments c
 		JOIN articles a ON c.article_id = a.id
 		WHERE c.user_id = $1
+			AND c.deleted_at IS NULL
 			AND c.created_at >= NOW() - INTERVAL '30 days'
 			AND a.deleted_at IS NULL
 		ORDER BY c.created_at DESC
@@ -77,5 +85,24 @@ func (r *Repository) GetUserActivity(userID string) (*UserActivity, error) {
 	}
 	activity.Comments = comments
 
+	// Get recent bookmarks (last 30 days, max 10)
+	bookmarksQuery := `
+		SELECT b.article_id, a.title as article_title, b.created_at
+		FROM bookmarks b
+		JOIN articles a ON b.article_id = a.id
+		WHERE b.user_id = $1
+			AND b.created_at >= NOW() - INTERVAL '30 days'
+			AND a.deleted_at IS NULL
+		ORDER BY b.created_at DESC
+		LIMIT 10
+	`
+
+	bookmarks := []UserBookmarkActivity{}
+	err = r.db.Select(&bookmarks, bookmarksQuery, userID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	activity.Bookmarks = bookmarks
+
 	return activity, nil
 }
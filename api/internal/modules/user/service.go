@@ -8,7 +8,7 @@ func NewService(repo *Repository) *Service {
 	return &Service{repo: repo}
 }
 
-// GetUserActivity retrieves user's recent activity (likes and comments)
+// GetUserActivity retrieves user's recent activity (likes, comments and bookmarks)
 func (s *Service) GetUserActivity(userID string) (*UserActivity, error) {
 	return s.repo.GetUserActivity(userID)
 }
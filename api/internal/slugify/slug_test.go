@@ -0,0 +1,15 @@
+package slugify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	assert.Equal(t, "hello-world-2025", Make("  Hello, World! 2025 "))
+	assert.Equal(t, "neyronnye-seti-i-obyasnimost", Make("Нейронные сети и объяснимость"))
+	assert.Equal(t, "zhasandy-intellekt-qazaqstanda", Make("Жасанды интеллект Қазақстанда"))
+	assert.Equal(t, "go-i-rust", Make("Go и Rust"))
+	assert.Equal(t, "", Make("日本語 !!!"))
+}
@@ -1,16 +1,21 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/ai-dala/api/internal/auth"
 	"github.com/ai-dala/api/internal/database"
 	"github.com/ai-dala/api/internal/http/server"
 	"github.com/ai-dala/api/internal/modules/articles"
 	"github.com/ai-dala/api/internal/modules/categories"
+	"github.com/ai-dala/api/internal/modules/news"
+	"github.com/ai-dala/api/internal/modules/reports"
+	"github.com/ai-dala/api/internal/modules/seo"
 	"github.com/ai-dala/api/internal/modules/tags"
 	"github.com/ai-dala/api/internal/modules/uploads"
 	"github.com/ai-dala/api/internal/modules/user"
@@ -51,6 +56,12 @@ func main() {
 	// Initialize Auth Service
 	authService := auth.NewJWTService("my-secret-key")
 
+	// Initialize token verifier (OIDC discovery is cached for the process lifetime)
+	verifier := auth.NewVerifier(auth.VerifierConfigFromEnv())
+	if err := verifier.Discover(context.Background()); err != nil {
+		log.Printf("OIDC discovery failed, will retry on first request: %v", err)
+	}
+
 	// Initialize Tags Module
 	tagsRepo := tags.NewRepository(db)
 	tagsService := tags.NewService(tagsRepo)
@@ -64,8 +75,29 @@ func main() {
 
 	// Initialize Articles Module
 	articlesRepo := articles.NewRepository(dbx)
-	articlesService := articles.NewService(articlesRepo)
-	articlesHandler := articles.NewHandler(articlesService)
+	articlesService := articles.NewService(articlesRepo, articles.ConfigFromEnv())
+	articlesHandler := articles.NewHandler(articlesService, verifier)
+
+	// Start the scheduled publishing worker
+	schedulerInterval, err := time.ParseDuration(fallback(os.Getenv("SCHEDULER_INTERVAL"), "1m"))
+	if err != nil {
+		log.Fatalf("invalid SCHEDULER_INTERVAL: %v", err)
+	}
+	go articles.NewScheduler(articlesRepo, schedulerInterval).Run(context.Background())
+
+	// Start the article stats rollup and trending worker
+	statsInterval, err := time.ParseDuration(fallback(os.Getenv("STATS_ROLLUP_INTERVAL"), "15m"))
+	if err != nil {
+		log.Fatalf("invalid STATS_ROLLUP_INTERVAL: %v", err)
+	}
+	go articles.NewStatsRollup(articlesRepo, statsInterval).Run(context.Background())
+
+	// Start the worker embedding published articles for semantic search
+	embeddingsInterval, err := time.ParseDuration(fallback(os.Getenv("EMBEDDINGS_INTERVAL"), "10m"))
+	if err != nil {
+		log.Fatalf("invalid EMBEDDINGS_INTERVAL: %v", err)
+	}
+	go articles.NewEmbeddingIndexer(articlesService, embeddingsInterval).Run(context.Background())
 
 	// Initialize Uploads Module
 	uploadsHandler := uploads.NewHandler("/uploads/images")
@@ -73,10 +105,44 @@ func main() {
 	// Initialize User Module
 	userRepo := user.NewRepository(dbx)
 	userService := user.NewService(userRepo)
-	userHandler := user.NewHandler(userService)
+	userHandler := user.NewHandler(userService, verifier)
+
+	// Initialize News Module
+	newsRepo := news.NewRepository(dbx)
+	newsService := news.NewService(newsRepo)
+	newsHandler := news.NewHandler(newsService, verifier)
+
+	// Start the news feed ingester when feeds are configured
+	if feedSources := news.FeedSourcesFromEnv(); len(feedSources) > 0 {
+		ingestInterval, err := time.ParseDuration(fallback(os.Getenv("NEWS_INGEST_INTERVAL"), "30m"))
+		if err != nil {
+			log.Fatalf("invalid NEWS_INGEST_INTERVAL: %v", err)
+		}
+		go news.NewIngester(newsService, feedSources, ingestInterval).Run(context.Background())
+	}
+
+	// Public web site address used for absolute links in feeds and sitemaps
+	siteURL := fallback(os.Getenv("PUBLIC_SITE_URL"), "http://localhost:3000")
+
+	// Initialize SEO Module
+	seoRepo := seo.NewRepository(dbx)
+	seoService := seo.NewService(seoRepo, siteURL)
+	seoHandler := seo.NewHandler(seoService, seo.RobotsConfigFromEnv(), siteURL)
+
+	// Initialize Reports Module
+	reportsRepo := reports.NewRepository(dbx)
+	reportsService := reports.NewService(reportsRepo, map[string]reports.Target{
+		reports.TargetArticle: articles.NewArticleReports(articlesService),
+		reports.TargetComment: articles.NewCommentReports(articlesService),
+	}, reports.ConfigFromEnv())
+	reportsHandler := reports.NewHandler(reportsService, verifier)
 
 	// Initialize Server
-	srv := server.NewServer(authService, tagsHandler, categoriesHandler, articlesHandler, uploadsHandler, userHandler)
+	srv := server.NewServer(authService, verifier, tagsHandler, categoriesHandler, articlesHandler, uploadsHandler, userHandler, newsHandler, seoHandler, reportsHandler, server.Content{
+		News:     newsService,
+		Articles: articlesService,
+		SiteURL:  siteURL,
+	})
 
 	mux := http.NewServeMux()
 	srv.RegisterRoutes(mux)